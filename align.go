@@ -0,0 +1,155 @@
+package jman
+
+// maxAlignCells bounds the size of the table used to align two arrays. Beyond it the
+// items left after trimming the common prefix and suffix are compared index by index.
+const maxAlignCells = 1 << 16
+
+// edit is a step of an alignment that is not a match: a changed item if both indices are set,
+// otherwise an item removed from expected or inserted into actual, with the other index -1.
+type edit struct {
+	expected, actual int
+}
+
+// align aligns n expected items with m actual items along their longest common subsequence
+// and returns the edits between them. Of the longest common subsequences, the one matching the
+// most items that keep their index is used, so that an item in the same place in both arrays is
+// not reported as removed and inserted again. Within each gap between matched items, removed and
+// inserted items are paired up in order as changed items.
+func align(n, m int, equal func(i, j int) bool) []edit {
+	start := 0
+	for start < n && start < m && equal(start, start) {
+		start++
+	}
+	endN, endM := n, m
+	for endN > start && endM > start && equal(endN-1, endM-1) {
+		endN--
+		endM--
+	}
+
+	rows, cols := endN-start, endM-start
+	if rows*cols > maxAlignCells {
+		return editsByIndex(start, endN, endM)
+	}
+
+	// lcs[i][j] scores the best common subsequence of the items from start+i and start+j on: its length
+	// weighted by more than the number of matches that can keep their index, plus that number
+	weight := min(rows, cols) + 1
+	matchScore := func(i, j int) int {
+		if i == j {
+			return weight + 1
+		}
+		return weight
+	}
+	eq := make([][]bool, rows)
+	lcs := make([][]int, rows+1)
+	lcs[rows] = make([]int, cols+1)
+	for i := rows - 1; i >= 0; i-- {
+		eq[i] = make([]bool, cols)
+		lcs[i] = make([]int, cols+1)
+		for j := cols - 1; j >= 0; j-- {
+			lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			if equal(start+i, start+j) {
+				eq[i][j] = true
+				lcs[i][j] = max(lcs[i][j], lcs[i+1][j+1]+matchScore(i, j))
+			}
+		}
+	}
+
+	var (
+		edits             []edit
+		removed, inserted []int
+	)
+	flush := func() {
+		edits = append(edits, pairEdits(removed, inserted)...)
+		removed, inserted = nil, nil
+	}
+	for i, j := 0, 0; i < rows || j < cols; {
+		switch {
+		case i < rows && j < cols && eq[i][j] && lcs[i][j] == lcs[i+1][j+1]+matchScore(i, j):
+			flush()
+			i++
+			j++
+		case j < cols && (i == rows || lcs[i][j+1] >= lcs[i+1][j]):
+			inserted = append(inserted, start+j)
+			j++
+		default:
+			removed = append(removed, start+i)
+			i++
+		}
+	}
+	flush()
+	return edits
+}
+
+// editsByIndex pairs the expected items from start to endN with the actual items from start to endM by index.
+func editsByIndex(start, endN, endM int) []edit {
+	var removed, inserted []int
+	for i := start; i < endN; i++ {
+		removed = append(removed, i)
+	}
+	for j := start; j < endM; j++ {
+		inserted = append(inserted, j)
+	}
+	return pairEdits(removed, inserted)
+}
+
+func pairEdits(removed, inserted []int) []edit {
+	var edits []edit
+	paired := min(len(removed), len(inserted))
+	for k := range paired {
+		edits = append(edits, edit{expected: removed[k], actual: inserted[k]})
+	}
+	for _, i := range removed[paired:] {
+		edits = append(edits, edit{expected: i, actual: -1})
+	}
+	for _, j := range inserted[paired:] {
+		edits = append(edits, edit{expected: -1, actual: j})
+	}
+	return edits
+}
+
+// assign pairs each expected item with one of its candidate actual items, the indices of the m actual
+// items equal to it, so that as many expected items as possible are paired and no actual item is paired
+// twice. It returns the index of the actual item paired with each expected item, -1 if there is none.
+func assign(candidates [][]int, m int) []int {
+	pairedWith := make([]int, m)
+	for j := range pairedWith {
+		pairedWith[j] = -1
+	}
+
+	// augment looks for a free actual item for i, moving the expected items already paired along the way
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range candidates[i] {
+			if pairedWith[j] < 0 {
+				pairedWith[j] = i
+				return true
+			}
+		}
+		for _, j := range candidates[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if augment(pairedWith[j], seen) {
+				pairedWith[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range candidates {
+		augment(i, make([]bool, m))
+	}
+
+	pairs := make([]int, len(candidates))
+	for i := range pairs {
+		pairs[i] = -1
+	}
+	for j, i := range pairedWith {
+		if i >= 0 {
+			pairs[i] = j
+		}
+	}
+	return pairs
+}
@@ -1,9 +1,12 @@
 package jman
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"slices"
+	"strings"
 )
 
 // Arr represents a JSON array. It implements the Equaler interface for deep equality checks.
@@ -13,8 +16,12 @@ type Arr []any
 // will be converted to Arr or Obj, and other simple types will be converted to their
 // corresponding types (bool, string, float64).
 func (a *Arr) UnmarshalJSON(data []byte) error {
+	return a.unmarshal(data, floatNumbers)
+}
+
+func (a *Arr) unmarshal(data []byte, numbers numberMode) error {
 	var raw []any
-	if err := json.Unmarshal(data, &raw); err != nil {
+	if err := decodeJSON(data, &raw, numbers); err != nil {
 		return err
 	}
 
@@ -28,53 +35,29 @@ func (a *Arr) UnmarshalJSON(data []byte) error {
 // It uses the provided options to customize the equality check, such as ignoring array order.
 // If the actual value is not a valid JSON array, it returns an error.
 func (a Arr) Equal(t T, other any, optFuncs ...optsFunc) {
-	opts := equalOptions{}
-
-	for _, o := range optFuncs {
-		o(&opts)
-	}
-
-	if err := opts.valid(); err != nil {
-		t.Fatalf(fmt.Sprintf("invalid options: %v", err))
-	}
-
-	// check if other value is an array
-	switch v := other.(type) {
-	case string:
-		if v[0] == '{' {
-			t.Fatalf("can't compare array with json object")
-		}
-	case []byte:
-		if v[0] == '{' {
-			t.Fatalf("can't compare array with json object")
-		}
-	case Obj:
-		t.Fatalf("can't compare array with json object")
-	}
-
-	act := New[Arr](t, other)
-
-	a, err := normalize(a)
-	if err != nil {
-		t.Fatalf(fmt.Sprintf("expected is invalid json: %v", err))
+	if h, ok := t.(helperT); ok {
+		h.Helper()
 	}
+	Equal(t, a, other, optFuncs...)
+}
 
-	diffs := compareArrays(base, a, act, opts)
-	if len(diffs) > 0 {
-		t.Fatalf(fmt.Sprintf("expected not equal to actual:\nexpected %s\nactual %s\n\n%s", a.String(t), act.String(t), diffs.report()))
+func compareArrays(path *location, expected, actual Arr, opts equalOptions) differences {
+	if fields, ok := opts.keyFields(path); ok {
+		return compareArraysByKey(path, fields, expected, actual, opts)
 	}
-}
 
-func compareArrays(path string, expected, actual Arr, opts equalOptions) differences {
 	var diffs differences
 	if len(expected) != len(actual) {
 		diffs = append(diffs, difference{
-			path: path,
-			diff: fmt.Sprintf("expected %d items - got %d items", len(expected), len(actual)),
+			path:     path,
+			diff:     fmt.Sprintf("expected %d items - got %d items", len(expected), len(actual)),
+			kind:     DiffLengthMismatch,
+			expected: expected,
+			actual:   actual,
 		})
 	}
 
-	if slices.Contains(opts.ignoreArrayOrder, path) {
+	if opts.ignoreArrayOrder.match(path) {
 		comparedDiffs := compareArraysIgnoreOrder(path, expected, actual, opts)
 		diffs = append(diffs, comparedDiffs...)
 	} else {
@@ -84,37 +67,422 @@ func compareArrays(path string, expected, actual Arr, opts equalOptions) differe
 	return diffs
 }
 
-func compareArraysIgnoreOrder(path string, expected, actual Arr, opts equalOptions) differences {
+// compareArraysIgnoreOrder pairs every expected item with a different equal actual item. Expected objects and
+// arrays left without one are reported with the differences to the closest actual item that is also left over.
+//
+// Expected items that can only equal identical values are paired through their canonical form, so that only
+// items with placeholders or relaxed by the options are compared with every actual item.
+func compareArraysIgnoreOrder(path *location, expected, actual Arr, opts equalOptions) differences {
+	pairs := make([]int, len(expected))
+	paired := make([]bool, len(actual))
+
+	actualByCanonical := map[string][]int{}
+	for j, v := range actual {
+		key := canonical(v, opts)
+		actualByCanonical[key] = append(actualByCanonical[key], j)
+	}
+
+	var loose []int
+	for i, item := range expected {
+		pairs[i] = -1
+		if !opts.exact(path.item(i), item) {
+			loose = append(loose, i)
+			continue
+		}
+		key := canonical(item, opts)
+		if js := actualByCanonical[key]; len(js) > 0 {
+			pairs[i], paired[js[0]] = js[0], true
+			actualByCanonical[key] = js[1:]
+		}
+	}
+
+	// an actual item paired with an exact item is identical to any other it could take, so
+	// pairing the loose items among the remaining ones still pairs as many items as possible
+	var unpaired []int
+	for j := range actual {
+		if !paired[j] {
+			unpaired = append(unpaired, j)
+		}
+	}
+	bySignature := map[string]map[string][]int{}
+	candidates := make([][]int, len(loose))
+	for k, i := range loose {
+		others := unpaired
+		// only the actual objects with the same exact fields can equal an expected object
+		if obj, ok := expected[i].(Obj); ok {
+			if keys := exactKeys(path.item(i), obj, opts); len(keys) > 0 {
+				set := fmt.Sprintf("%q", keys)
+				if bySignature[set] == nil {
+					bySignature[set] = map[string][]int{}
+					for _, j := range unpaired {
+						sig := signature(actual[j], keys, opts)
+						bySignature[set][sig] = append(bySignature[set][sig], j)
+					}
+				}
+				others = bySignature[set][signature(obj, keys, opts)]
+			}
+		}
+
+		for _, j := range others {
+			if equal, _ := compareValues(path.item(i), expected[i], actual[j], opts.trial()); equal {
+				candidates[k] = append(candidates[k], j)
+			}
+		}
+	}
 	var diffs differences
+	for k, j := range assign(candidates, len(actual)) {
+		if j < 0 {
+			continue
+		}
+		pairs[loose[k]], paired[j] = j, true
+		// the candidates were only tried, so compare the pair again to capture its values,
+		// which can differ from the values captured for the pairs before
+		if equal, diff := compareValues(path.item(loose[k]), expected[loose[k]], actual[j], opts); !equal {
+			diffs = append(diffs, diff)
+		}
+	}
+
 	for i, item := range expected {
-		found := slices.ContainsFunc(actual, func(v any) bool {
-			equal, _ := compareValues(fmt.Sprintf("%s.%d", path, i), item, v, opts)
-			return equal
-		})
-		if found {
+		if pairs[i] >= 0 {
 			continue
 		}
 
 		d := difference{
-			path: fmt.Sprintf("%s.%d", path, i),
-			diff: "not found in actual",
+			path:     path.item(i),
+			diff:     "not found in actual",
+			kind:     DiffMissing,
+			expected: item,
+		}
+		j, closest, ok := closestItem(path, item, actual, paired, opts)
+		if ok && len(closest) == 0 {
+			// the closest item is equal after all, so it is paired like the candidates
+			paired[j] = true
+			if equal, diff := compareValues(path.item(i), item, actual[j], opts); !equal {
+				diffs = append(diffs, diff)
+			}
+			continue
+		}
+		if ok {
+			report := strings.TrimSuffix(closest.report(), "\n")
+			d.diff += fmt.Sprintf(", closest match %s differs by:\n  %s", path.item(j), strings.ReplaceAll(report, "\n", "\n  "))
 		}
 		diffs = append(diffs, d)
 	}
 	return diffs
 }
 
-func compareArraysStrictOrder(path string, expected, actual Arr, opts equalOptions) differences {
+// closestItem finds the actual item not yet paired with the fewest differences to an expected object or array.
+// The differences are empty if the item is equal to it.
+func closestItem(path *location, item any, actual Arr, paired []bool, opts equalOptions) (int, differences, bool) {
+	var (
+		closestIndex = -1
+		closest      differences
+	)
+	for j, v := range actual {
+		if paired[j] || !sameContainer(item, v) {
+			continue
+		}
+		equal, diff := compareValues(path.item(j), item, v, opts.trial())
+		if equal {
+			return j, nil, true
+		}
+		diffs := differences{diff}
+		if closestIndex < 0 || len(diffs.flatten()) < len(closest.flatten()) {
+			closestIndex, closest = j, diffs
+		}
+	}
+	return closestIndex, closest, closestIndex >= 0
+}
+
+// sameContainer reports whether both values are objects or both are arrays.
+func sameContainer(a, b any) bool {
+	switch a.(type) {
+	case Obj:
+		_, ok := b.(Obj)
+		return ok
+	case Arr:
+		_, ok := b.(Arr)
+		return ok
+	}
+	return false
+}
+
+// compareArraysByKey pairs the items of expected and actual by their key fields. Items with the same key
+// are paired in order, so duplicate keys in expected need as many duplicates in actual.
+func compareArraysByKey(path *location, fields []string, expected, actual Arr, opts equalOptions) differences {
+	var diffs differences
+
+	actualByKey := map[string][]int{}
+	for i, item := range actual {
+		label, ok := itemLabel(item, fields)
+		if !ok {
+			diffs = append(diffs, difference{
+				path:   path.item(i),
+				diff:   fmt.Sprintf("actual item has no key %s", strings.Join(fields, ", ")),
+				kind:   DiffTypeMismatch,
+				actual: item,
+			})
+			continue
+		}
+		actualByKey[label] = append(actualByKey[label], i)
+	}
+
+	for i, item := range expected {
+		label, ok := itemLabel(item, fields)
+		if !ok {
+			diffs = append(diffs, difference{
+				path:     path.item(i),
+				diff:     fmt.Sprintf("expected item has no key %s", strings.Join(fields, ", ")),
+				kind:     DiffTypeMismatch,
+				expected: item,
+			})
+			continue
+		}
+
+		matches := actualByKey[label]
+		if len(matches) == 0 {
+			diffs = append(diffs, difference{
+				path:     path.keyedItem(i, label),
+				diff:     "not found in actual",
+				kind:     DiffMissing,
+				expected: item,
+			})
+			continue
+		}
+		actualByKey[label] = matches[1:]
+
+		equal, diff := compareValues(path.keyedItem(i, label), item, actual[matches[0]], opts)
+		if !equal {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for i, item := range actual {
+		label, ok := itemLabel(item, fields)
+		if !ok {
+			continue
+		}
+		// the indices left over for a key are the items not paired with an expected item
+		if slices.Contains(actualByKey[label], i) {
+			diffs = append(diffs, difference{
+				path:   path.keyedItem(i, label),
+				diff:   "unexpected item",
+				kind:   DiffUnexpected,
+				actual: item,
+			})
+		}
+	}
+	return diffs
+}
+
+// itemLabel renders the key fields of an array item, e.g. id=42 or id=42,region="eu".
+// It returns false if the item is not an object with all of the key fields.
+func itemLabel(item any, fields []string) (string, bool) {
+	obj, ok := item.(Obj)
+	if !ok {
+		return "", false
+	}
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		value, exists := obj[field]
+		if !exists {
+			return "", false
+		}
+		// items are normalized, so marshaling can't fail
+		data, _ := json.Marshal(value)
+		parts[i] = field + "=" + string(data)
+	}
+	return strings.Join(parts, ","), true
+}
+
+// compareArraysStrictOrder aligns the items of expected and actual, so that items inserted or removed
+// are reported as such instead of shifting every following item into a mismatch.
+func compareArraysStrictOrder(path *location, expected, actual Arr, opts equalOptions) differences {
+	items := newItemIdentities(path, expected, actual, opts)
+
+	var edits []edit
+	if len(expected) == len(actual) && !items.shifted() {
+		// no item equals one at another index, so no alignment pairs more items than pairing them by index
+		edits = editsByIndex(0, len(expected), len(actual))
+	} else {
+		edits = align(len(expected), len(actual), items.equal)
+	}
 	var diffs differences
+	if opts.captures != nil {
+		diffs = captureMatches(path, expected, actual, edits, opts)
+	}
+	for _, e := range edits {
+		switch {
+		case e.actual < 0:
+			diffs = append(diffs, difference{
+				path:     path.item(e.expected),
+				diff:     fmt.Sprintf("%s removed from index %d", jsonText(expected[e.expected]), e.expected),
+				kind:     DiffMissing,
+				expected: expected[e.expected],
+			})
+		case e.expected < 0:
+			diffs = append(diffs, difference{
+				path:   path.item(e.actual),
+				diff:   fmt.Sprintf("%s inserted at index %d", jsonText(actual[e.actual]), e.actual),
+				kind:   DiffUnexpected,
+				actual: actual[e.actual],
+			})
+		default:
+			equal, diff := compareValues(path.item(e.expected), expected[e.expected], actual[e.actual], opts)
+			if equal {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// jsonText renders a normalized value as compact JSON for a message.
+func jsonText(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// values are normalized, so encoding can't fail
+	_ = enc.Encode(v)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// itemIdentities tells which items of two arrays can be equal without comparing them where possible.
+// Exact expected items, see equalOptions.exact, are equal to the actual items with the same canonical form,
+// so they get the same id. Other expected objects can only equal the actual objects with the same values
+// for their exact keys, so they are only compared with those.
+type itemIdentities struct {
+	path             *location
+	expected, actual Arr
+	opts             equalOptions
+	// expectedIDs are the ids of the canonical forms of the expected items, -1 for items that are not exact
+	expectedIDs, actualIDs []int
+	// keys are the exact keys of the expected objects that are not exact, signatures their values
+	keys       [][]string
+	signatures []string
+	// actualSignatures are the signatures of the actual items for each set of keys
+	actualSignatures map[string][]string
+}
+
+func newItemIdentities(path *location, expected, actual Arr, opts equalOptions) *itemIdentities {
+	ids := map[string]int{}
+	id := func(v any) int {
+		key := canonical(v, opts)
+		if _, ok := ids[key]; !ok {
+			ids[key] = len(ids)
+		}
+		return ids[key]
+	}
+
+	items := &itemIdentities{
+		path:             path,
+		expected:         expected,
+		actual:           actual,
+		opts:             opts,
+		expectedIDs:      make([]int, len(expected)),
+		actualIDs:        make([]int, len(actual)),
+		keys:             make([][]string, len(expected)),
+		signatures:       make([]string, len(expected)),
+		actualSignatures: map[string][]string{},
+	}
 	for i, item := range expected {
-		if i >= len(actual) {
+		items.expectedIDs[i] = -1
+		if opts.exact(path.item(i), item) {
+			items.expectedIDs[i] = id(item)
 			continue
 		}
-		equal, diff := compareValues(fmt.Sprintf("%s.%d", path, i), item, actual[i], opts)
-		if equal {
+		if obj, ok := item.(Obj); ok {
+			items.keys[i] = exactKeys(path.item(i), obj, opts)
+			items.signatures[i] = signature(obj, items.keys[i], opts)
+		}
+	}
+	for j, item := range actual {
+		items.actualIDs[j] = id(item)
+	}
+	return items
+}
+
+// mayEqual reports whether the expected item i can be equal to the actual item j, and whether that is certain.
+func (it *itemIdentities) mayEqual(i, j int) (possible, certain bool) {
+	if it.expectedIDs[i] >= 0 {
+		return it.expectedIDs[i] == it.actualIDs[j], true
+	}
+	if len(it.keys[i]) == 0 {
+		return true, false
+	}
+	set := fmt.Sprintf("%q", it.keys[i])
+	sigs, ok := it.actualSignatures[set]
+	if !ok {
+		sigs = make([]string, len(it.actual))
+		for k, v := range it.actual {
+			sigs[k] = signature(v, it.keys[i], it.opts)
+		}
+		it.actualSignatures[set] = sigs
+	}
+	return sigs[j] == it.signatures[i], false
+}
+
+// equal reports whether the expected item i equals the actual item j, only comparing them if it has to.
+func (it *itemIdentities) equal(i, j int) bool {
+	possible, certain := it.mayEqual(i, j)
+	if !possible || certain {
+		return possible
+	}
+	equal, _ := compareValues(it.path.item(i), it.expected[i], it.actual[j], it.opts.trial())
+	return equal
+}
+
+// shifted reports whether an expected item may equal an actual item at another index,
+// which is the only case in which aligning the arrays can pair more items than their indices.
+func (it *itemIdentities) shifted() bool {
+	positions := map[int][]int{}
+	for j, id := range it.actualIDs {
+		positions[id] = append(positions[id], j)
+	}
+	for i := range it.expected {
+		if id := it.expectedIDs[i]; id >= 0 {
+			if js := positions[id]; len(js) > 1 || len(js) == 1 && js[0] != i {
+				return true
+			}
+			continue
+		}
+		for j := range it.actual {
+			if possible, _ := it.mayEqual(i, j); possible && i != j {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// captureMatches compares the items matched by an alignment again to capture the values of their placeholders,
+// as the alignment only tried whether they are equal. Matched items are those in no edit, paired in order.
+func captureMatches(path *location, expected, actual Arr, edits []edit, opts equalOptions) differences {
+	editedExpected := make([]bool, len(expected))
+	editedActual := make([]bool, len(actual))
+	for _, e := range edits {
+		if e.expected >= 0 {
+			editedExpected[e.expected] = true
+		}
+		if e.actual >= 0 {
+			editedActual[e.actual] = true
+		}
+	}
+
+	var diffs differences
+	j := 0
+	for i := range expected {
+		if editedExpected[i] {
 			continue
 		}
-		diffs = append(diffs, diff)
+		for editedActual[j] {
+			j++
+		}
+		if equal, diff := compareValues(path.item(i), expected[i], actual[j], opts); !equal {
+			diffs = append(diffs, diff)
+		}
+		j++
 	}
 	return diffs
 }
@@ -122,6 +490,9 @@ func compareArraysStrictOrder(path string, expected, actual Arr, opts equalOptio
 // String returns the JSON representation of the Arr as a string.
 // It fails if there is an error during marshaling.
 func (a Arr) String(t T) string {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	data, err := json.Marshal(a)
 	if err != nil {
 		t.Fatalf(fmt.Sprintf("error marshaling JSON object: %v", err))
@@ -132,6 +503,9 @@ func (a Arr) String(t T) string {
 // Bytes returns the JSON representation of the Arr as a byte slice.
 // It fails if there is an error during marshaling.
 func (a Arr) MustBytes(t T) []byte {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	data, err := json.Marshal(a)
 	if err != nil {
 		t.Fatalf(fmt.Sprintf("error marshaling JSON object: %v", err))
@@ -140,23 +514,67 @@ func (a Arr) MustBytes(t T) []byte {
 }
 
 // Get retrieves a value from the Arr at the specified path.
-// The path is a JSONPath-like string that specifies the location of the value.
+// The path is a JSONPath string that specifies the location of the value.
+// Paths that can match several values, e.g. with a wildcard, must match exactly one; use GetAll otherwise.
 // If the path is invalid or the value cannot be retrieved, it calls t.Fatal
 func (a Arr) Get(t T, path string) any {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	val, err := a.GetE(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return val
+}
+
+// GetE retrieves a value from the Arr at the specified path like Get, but returns an error instead of failing a test.
+func (a Arr) GetE(path string) (any, error) {
 	normed, err := normalize(a)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("err normalizing arr: %v", err))
+		return nil, fmt.Errorf("err normalizing arr: %v", err)
 	}
 	val, err := getValue(path, normed)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("failed to get value at path '%s': %v", path, err))
+		return nil, fmt.Errorf("failed to get value at path '%s': %v", path, err)
 	}
+	return clone(val), nil
+}
 
-	return val
+// GetAll retrieves every value from the Arr matched by the specified path.
+// The path may use JSONPath wildcards ($.users[*].id), recursive descent ($..email),
+// slices ($.items[0:3]) and filters ($.items[?(@.status=="active")]).
+// If no values match, an empty Arr is returned. If the path is invalid, it calls t.Fatal
+func (a Arr) GetAll(t T, path string) Arr {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	vals, err := a.GetAllE(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return vals
+}
+
+// GetAllE retrieves every value from the Arr matched by the specified path like GetAll,
+// but returns an error instead of failing a test.
+func (a Arr) GetAllE(path string) (Arr, error) {
+	normed, err := normalize(a)
+	if err != nil {
+		return nil, fmt.Errorf("err normalizing arr: %v", err)
+	}
+	vals, err := getAll(path, normed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values at path '%s': %v", path, err)
+	}
+	return clone(vals).(Arr), nil
 }
 
 // GetString functions like Get but attempts to convert to string. Fails if the value at the path is not a string.
 func (a Arr) GetString(t T, path string) string {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := a.Get(t, path)
 	if str, ok := val.(string); ok {
 		return str
@@ -167,16 +585,56 @@ func (a Arr) GetString(t T, path string) string {
 
 // GetNumber functions like Get but attempts to convert to float64. Fails if the value at the path is not a number.
 func (a Arr) GetNumber(t T, path string) float64 {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := a.Get(t, path)
-	if num, ok := val.(float64); ok {
+	switch num := val.(type) {
+	case float64:
 		return num
+	case json.Number:
+		return floatValue(num)
 	}
 	t.Fatalf(fmt.Sprintf("expected number at path '%s', got %T", path, val))
 	return 0
 }
 
+// GetInt64 functions like Get but attempts to convert to int64. Fails if the value at the path is not an integer
+// or out of the range of an int64. Use WithUseNumber when parsing to keep the precision of integers beyond 2^53.
+func (a Arr) GetInt64(t T, path string) int64 {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	num := a.GetBigInt(t, path)
+	if num == nil {
+		return 0
+	}
+	if !num.IsInt64() {
+		t.Fatalf(fmt.Sprintf("expected int64 at path '%s', got %v which is out of range", path, num))
+		return 0
+	}
+	return num.Int64()
+}
+
+// GetBigInt functions like Get but attempts to convert to *big.Int. Fails if the value at the path is not an integer.
+// Use WithUseNumber when parsing to keep the precision of integers beyond 2^53.
+func (a Arr) GetBigInt(t T, path string) *big.Int {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	val := a.Get(t, path)
+	if num, ok := integerValue(val); ok {
+		return num
+	}
+	t.Fatalf(fmt.Sprintf("expected integer at path '%s', got %T (%v)", path, val, val))
+	return nil
+}
+
 // GetBool functions like Get but attempts to convert to bool. Fails if the value at the path is not a boolean.
 func (a Arr) GetBool(t T, path string) bool {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := a.Get(t, path)
 	if b, ok := val.(bool); ok {
 		return b
@@ -187,6 +645,9 @@ func (a Arr) GetBool(t T, path string) bool {
 
 // GetArray functions like Get but attempts to convert to Arr. Fails if the value at the path is not an array.
 func (a Arr) GetArray(t T, path string) Arr {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := a.Get(t, path)
 	if arr, ok := val.(Arr); ok {
 		return arr
@@ -197,6 +658,9 @@ func (a Arr) GetArray(t T, path string) Arr {
 
 // GetObject functions like Get but attempts to convert to Obj. Fails if the value at the path is not an object.
 func (a Arr) GetObject(t T, path string) Obj {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := a.Get(t, path)
 	if obj, ok := val.(Obj); ok {
 		return obj
@@ -206,7 +670,8 @@ func (a Arr) GetObject(t T, path string) Obj {
 }
 
 // Set sets a value at the specified path in the Arr.
-// The path is a JSONPath-like string that specifies the location of the value.
+// The path is a JSONPath string that specifies the location of the value.
+// Paths that can match several values, e.g. $.users[*].active, set every matched location.
 // If the path is invalid or the value cannot be set, it fails.
 // After setting the value, it normalizes the Arr to ensure it is valid JSON.
 // If normalization fails, it calls Fatalf on T.
@@ -214,13 +679,91 @@ func (a Arr) GetObject(t T, path string) Obj {
 // The value can be of any type, but it will be normalized to one of the supported JSON types:
 // bool, string, float64, Obj, or Arr.
 func (a Arr) Set(t T, path string, value any) {
-	if err := setByPath(a, path, value); err != nil {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := a.SetE(path, value); err != nil {
 		t.Fatalf(err.Error())
 	}
+}
+
+// SetE sets a value at the specified path in the Arr like Set, but returns an error instead of failing a test.
+func (a Arr) SetE(path string, value any) error {
+	if err := setByPath(a, path, value); err != nil {
+		return err
+	}
 
 	normalA, err := normalize(a)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("invalid json array: %v", err))
+		return fmt.Errorf("invalid json array: %v", err)
 	}
 	copy(a, normalA)
+	return nil
+}
+
+// Delete removes the value at the specified path from the Arr.
+// The path can address a key or an array item; paths matching several values delete all of them.
+// Items removed from the root array shrink the Arr in place.
+// If the path is invalid or nothing is found at the path, it fails.
+func (a *Arr) Delete(t T, path string) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := a.DeleteE(path); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// DeleteE removes the value at the specified path from the Arr like Delete, but returns an error instead of failing a test.
+func (a *Arr) DeleteE(path string) error {
+	updated, err := deleteByPath(*a, path)
+	if err != nil {
+		return err
+	}
+	*a = updated.(Arr)
+	return nil
+}
+
+// Append appends values to the array at the specified path in the Arr. Use "$" to append to the Arr itself.
+// If nothing exists at a path without wildcards, a new array is created.
+// The values are normalized like in Set.
+func (a *Arr) Append(t T, path string, values ...any) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := a.AppendE(path, values...); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// AppendE appends values to the array at the specified path in the Arr like Append, but returns an error instead of failing a test.
+func (a *Arr) AppendE(path string, values ...any) error {
+	updated, err := appendByPath(*a, path, values...)
+	if err != nil {
+		return err
+	}
+	*a = updated.(Arr)
+	return nil
+}
+
+// Insert inserts value into the array at the specified path in the Arr before the item at index.
+// Use "$" to insert into the Arr itself. An index equal to the length of the array appends the value.
+// The value is normalized like in Set.
+func (a *Arr) Insert(t T, path string, index int, value any) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := a.InsertE(path, index, value); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// InsertE inserts value into the array at the specified path in the Arr like Insert, but returns an error instead of failing a test.
+func (a *Arr) InsertE(path string, index int, value any) error {
+	updated, err := insertByPath(*a, path, index, value)
+	if err != nil {
+		return err
+	}
+	*a = updated.(Arr)
+	return nil
 }
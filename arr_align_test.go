@@ -0,0 +1,149 @@
+package jman_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_StrictOrder_InsertedAtFront(t *testing.T) {
+	expected := jman.Arr{}
+	for i := range 50 {
+		expected = append(expected, jman.Obj{"id": i})
+	}
+	actual := append(jman.Arr{jman.Obj{"id": "new"}}, expected...)
+
+	diffs := jman.Diff(t, jman.Obj{"items": expected}, jman.Obj{"items": actual})
+
+	assert.Equal(t, []string{
+		"$.items expected 50 items - got 51 items",
+		`$.items.0 {"id":"new"} inserted at index 0`,
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_RemovedAndChanged(t *testing.T) {
+	expected := jman.Arr{"a", "b", jman.Obj{"id": 1, "name": "c"}, "d", "e", "f", "g", "h"}
+	actual := jman.Arr{"a", "b", jman.Obj{"id": 1, "name": "C"}, "d", "e", "f", "g"}
+
+	diffs := jman.Diff(t, expected, actual)
+
+	assert.Equal(t, []string{
+		"$ expected 8 items - got 7 items",
+		`$.2.name expected "c" - actual "C"`,
+		`$.7 "h" removed from index 7`,
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_ReplacedRun(t *testing.T) {
+	expected := jman.Arr{1, 2, 3, 4, 5}
+	actual := jman.Arr{1, 9, 8, 7, 5}
+
+	diffs := jman.Diff(t, expected, actual)
+
+	assert.Equal(t, []string{
+		"$.1 expected 2 - actual 9",
+		"$.2 expected 3 - actual 8",
+		"$.3 expected 4 - actual 7",
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_PrefersItemsKeepingTheirIndex(t *testing.T) {
+	expected := jman.Arr{1, 2, false, nil}
+	actual := jman.Arr{nil, 2, 1, false}
+
+	diffs := jman.Diff(t, expected, actual)
+
+	assert.Equal(t, []string{
+		"$.0 expected 1 - actual <nil>",
+		"$.2 1 inserted at index 2",
+		"$.3 null removed from index 3",
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_RendersItemsAsJSON(t *testing.T) {
+	diffs := jman.Diff(t, jman.Arr{"a"}, jman.Arr{"a", jman.Obj{"html": "<b>&</b>", "tags": jman.Arr{true}}})
+
+	assert.Equal(t, []string{
+		"$ expected 1 items - got 2 items",
+		`$.1 {"html":"<b>&</b>","tags":[true]} inserted at index 1`,
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_WithMatchers(t *testing.T) {
+	expected := jman.Arr{"$ANY", "b", "c"}
+	actual := jman.Arr{"x", "y", "b", "c"}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithMatchers(jman.EqualMatcher("$ANY", "x")))
+
+	assert.Equal(t, []string{
+		"$ expected 3 items - got 4 items",
+		`$.1 "y" inserted at index 1`,
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_EveryItemChanged(t *testing.T) {
+	expected, actual := changedItems(3, 1)
+
+	diffs := jman.Diff(t, expected, actual)
+
+	assert.Equal(t, []string{
+		"$.0.meta.version expected 1 - actual 2",
+		"$.1.meta.version expected 1 - actual 2",
+		"$.2.meta.version expected 1 - actual 2",
+	}, diffStrings(diffs))
+}
+
+func TestDiff_StrictOrder_PlaceholderObjectsAligned(t *testing.T) {
+	expected := jman.Arr{jman.Obj{"id": "$ID", "n": 1}, jman.Obj{"id": "$ID", "n": 2}}
+	actual := jman.Arr{jman.Obj{"id": "x", "n": 0}, jman.Obj{"id": "a", "n": 1}, jman.Obj{"id": "b", "n": 2}}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithMatchers(jman.NotEmpty("$ID")))
+
+	assert.Equal(t, []string{
+		"$ expected 2 items - got 3 items",
+		`$.0 {"id":"x","n":0} inserted at index 0`,
+	}, diffStrings(diffs))
+}
+
+func BenchmarkDiff_StrictOrder(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		expected, actual := changedItems(n, 1)
+		b.Run(fmt.Sprintf("every item changed/%d", n), func(b *testing.B) {
+			for range b.N {
+				jman.Diff(b, expected, actual)
+			}
+		})
+		expected, actual = changedItems(n, 10)
+		shifted := append(jman.Arr{jman.Obj{"id": "new"}}, actual[:n-1]...)
+		b.Run(fmt.Sprintf("inserted and changed/%d", n), func(b *testing.B) {
+			for range b.N {
+				jman.Diff(b, expected, shifted)
+			}
+		})
+	}
+}
+
+// changedItems returns n items and the same items with a nested field changed in every step-th one.
+func changedItems(n, step int) (jman.Arr, jman.Arr) {
+	expected := make(jman.Arr, n)
+	actual := make(jman.Arr, n)
+	for i := range n {
+		expected[i] = jman.Obj{"id": i, "meta": jman.Obj{"version": 1, "tags": jman.Arr{"a", "b"}}}
+		version := 1
+		if i%step == 0 {
+			version = 2
+		}
+		actual[i] = jman.Obj{"id": i, "meta": jman.Obj{"version": version, "tags": jman.Arr{"a", "b"}}}
+	}
+	return expected, actual
+}
+
+func diffStrings(diffs []jman.Difference) []string {
+	strs := make([]string, len(diffs))
+	for i, d := range diffs {
+		strs[i] = d.String()
+	}
+	return strs
+}
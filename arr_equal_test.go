@@ -12,3 +12,18 @@ func TestArr_Equal_IgnoreOrder_Base(t *testing.T) {
 
 	expected.Equal(t, actual, jman.WithIgnoreArrayOrder("$"))
 }
+
+func TestArr_Equal_Unequal_BaseLength(t *testing.T) {
+	expected := jman.Arr{"a", "b"}
+	actual := jman.Arr{"a"}
+
+	assertFatalf(t, `expected not equal to actual:
+expected ["a","b"]
+actual ["a"]
+
+$ expected 2 items - got 1 items
+$.1 "b" removed from index 1
+`, func(mt jman.T) {
+		expected.Equal(mt, actual)
+	})
+}
@@ -0,0 +1,142 @@
+package jman_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_IgnoreOrder_OneToOne(t *testing.T) {
+	diffs := jman.Diff(t, jman.Arr{1, 1}, jman.Arr{1, 2}, jman.WithIgnoreArrayOrder("$"))
+
+	assert.Equal(t, []string{"$.1 not found in actual"}, diffStrings(diffs))
+}
+
+func TestDiff_IgnoreOrder_ReassignsMatches(t *testing.T) {
+	// "$ANY" could take "a", but then "a" would be left without a match
+	expected := jman.Arr{"$ANY", "a"}
+	actual := jman.Arr{"a", "b"}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithIgnoreArrayOrder("$"), jman.WithMatchers(jman.NotEmpty("$ANY")))
+
+	assert.Empty(t, diffs)
+}
+
+func TestEqual_IgnoreOrder_ClosestMatch(t *testing.T) {
+	expected := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 1, "name": "alice", "email": "alice@example.com"},
+		jman.Obj{"id": 2, "name": "bob", "email": "bob@example.com"},
+	}}
+	actual := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 2, "name": "bob", "email": "bob@example.com"},
+		jman.Obj{"id": 3, "name": "carol", "email": "carol@example.com"},
+		jman.Obj{"id": 1, "name": "alice", "email": "alice@example.org"},
+	}}
+
+	expectedMsg := `expected not equal to actual:
+expected {"users":[{"email":"alice@example.com","id":1,"name":"alice"},{"email":"bob@example.com","id":2,"name":"bob"}]}
+actual {"users":[{"email":"bob@example.com","id":2,"name":"bob"},{"email":"carol@example.com","id":3,"name":"carol"},{"email":"alice@example.org","id":1,"name":"alice"}]}
+
+$.users expected 2 items - got 3 items
+$.users.0 not found in actual, closest match $.users.2 differs by:
+  $.users.2.email expected "alice@example.com" - actual "alice@example.org"
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, expected, actual, jman.WithIgnoreArrayOrder("$.users"))
+	})
+}
+
+func TestEqual_IgnoreOrder_RelaxedItems(t *testing.T) {
+	expected := jman.Arr{
+		jman.Obj{"id": 1, "tags": jman.Arr{"a", "b"}},
+		jman.Obj{"id": 2, "tags": jman.Arr{"c"}},
+	}
+	actual := jman.Arr{
+		jman.Obj{"id": 2, "tags": jman.Arr{"c"}, "etag": "x", "updatedAt": "today"},
+		jman.Obj{"id": 1, "tags": jman.Arr{"b", "a"}, "etag": "y", "updatedAt": "today"},
+	}
+
+	jman.Equal(t, expected, actual,
+		jman.WithIgnoreArrayOrder("$", "$.*.tags"),
+		jman.WithIgnoreKeys("etag"),
+		jman.WithIgnorePaths("$.*.updatedAt"),
+	)
+}
+
+func TestEqual_IgnoreOrder_PlaceholdersAndExactItems(t *testing.T) {
+	expected := jman.Arr{jman.Obj{"id": "$ID", "n": 1}, jman.Obj{"id": "b", "n": 1}, 1.5, nil}
+	actual := jman.Arr{nil, jman.Obj{"n": 1, "id": "b"}, 1.5, jman.Obj{"n": 1, "id": "a"}}
+
+	jman.Equal(t, expected, actual, jman.WithIgnoreArrayOrder("$"), jman.WithMatchers(jman.NotEmpty("$ID")))
+}
+
+func TestEqual_IgnoreOrder_NegativeZero(t *testing.T) {
+	expected := `[{"a": -0}, -0.0, [0]]`
+	actual := `[[-0], {"a": 0}, 0]`
+
+	jman.Equal(t, expected, actual, jman.WithIgnoreArrayOrder("$", "$.*"))
+	jman.Equal(t, expected, `[{"a": 0}, 0, [0]]`)
+}
+
+func BenchmarkEqual_IgnoreOrder(b *testing.B) {
+	for _, n := range []int{100, 1000, 3000} {
+		expected, actual := eventLogs(n, "")
+		b.Run(fmt.Sprintf("exact/%d", n), func(b *testing.B) {
+			for range b.N {
+				jman.Equal(b, expected, actual, jman.WithIgnoreArrayOrder("$"))
+			}
+		})
+	}
+}
+
+func BenchmarkEqual_IgnoreOrder_Placeholders(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		expected, actual := eventLogs(n, "$ID")
+		b.Run(fmt.Sprintf("placeholders/%d", n), func(b *testing.B) {
+			for range b.N {
+				jman.Equal(b, expected, actual, jman.WithIgnoreArrayOrder("$"), jman.WithMatchers(jman.NotEmpty("$ID")))
+			}
+		})
+	}
+}
+
+// eventLogs returns n events and the same events in reverse order, with the ids of
+// the expected events replaced by the placeholder if one is given.
+func eventLogs(n int, placeholder string) (jman.Arr, jman.Arr) {
+	expected := make(jman.Arr, n)
+	actual := make(jman.Arr, n)
+	for i := range n {
+		event := jman.Obj{
+			"id":      fmt.Sprintf("evt-%d", i),
+			"type":    "order.updated",
+			"payload": jman.Obj{"order": i, "items": jman.Arr{"a", "b"}},
+		}
+		actual[n-1-i] = event
+		if placeholder != "" {
+			event = jman.Obj{"id": placeholder, "type": event["type"], "payload": event["payload"]}
+		}
+		expected[i] = event
+	}
+	return expected, actual
+}
+
+func TestContains_IgnoreOrder_Placeholders(t *testing.T) {
+	expected := jman.Arr{
+		jman.Obj{"id": "$ID", "type": "created", "meta": jman.Obj{"v": 1}},
+		jman.Obj{"id": "$ID", "type": "updated", "meta": jman.Obj{"v": 2}},
+	}
+	actual := jman.Arr{
+		jman.Obj{"id": "b", "type": "updated", "meta": jman.Obj{"v": 2, "by": "bob"}, "at": 2},
+		jman.Obj{"id": "a", "type": "created", "meta": jman.Obj{"v": 1}, "at": 1},
+	}
+
+	jman.Contains(t, expected, actual, jman.WithIgnoreArrayOrder("$"), jman.WithMatchers(jman.NotEmpty("$ID")))
+
+	diffs := jman.Diff(t, expected, jman.Arr{actual[0], jman.Obj{"type": "created", "meta": jman.Obj{"v": 1}}},
+		jman.WithIgnoreArrayOrder("$"), jman.WithMatchers(jman.NotEmpty("$ID")), jman.WithAllowExtraKeys())
+	assert.Equal(t, []string{
+		"$.0 not found in actual, closest match $.1 differs by:\n  $.1.id not found in actual",
+	}, diffStrings(diffs))
+}
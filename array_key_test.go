@@ -0,0 +1,97 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_ArrayKey(t *testing.T) {
+	expected := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 1, "name": "alice"},
+		jman.Obj{"id": 2, "name": "bob"},
+	}}
+	actual := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 2, "name": "bob"},
+		jman.Obj{"id": 1, "name": "alice"},
+	}}
+
+	jman.Equal(t, expected, actual, jman.WithArrayKey("$.users", "id"))
+}
+
+func TestEqual_ArrayKey_Unequal(t *testing.T) {
+	expected := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 41, "email": "a@example.com"},
+		jman.Obj{"id": 42, "email": "b@example.com"},
+	}}
+	actual := jman.Obj{"users": jman.Arr{
+		jman.Obj{"id": 43, "email": "c@example.com"},
+		jman.Obj{"id": 42, "email": "x@example.com"},
+	}}
+
+	expectedMsg := `expected not equal to actual:
+expected {"users":[{"email":"a@example.com","id":41},{"email":"b@example.com","id":42}]}
+actual {"users":[{"email":"c@example.com","id":43},{"email":"x@example.com","id":42}]}
+
+$.users[id=41] not found in actual
+$.users[id=42].email expected "b@example.com" - actual "x@example.com"
+$.users[id=43] unexpected item
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, expected, actual, jman.WithArrayKey("$.users", "id"))
+	})
+}
+
+func TestDiff_ArrayKey_CompositeKey(t *testing.T) {
+	expected := jman.Arr{
+		jman.Obj{"id": 1, "region": "eu", "active": true},
+		jman.Obj{"id": 1, "region": "us", "active": true},
+	}
+	actual := jman.Arr{
+		jman.Obj{"id": 1, "region": "us", "active": false},
+		jman.Obj{"id": 1, "region": "eu", "active": true},
+		jman.Obj{"region": "eu"},
+	}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithArrayKey("$", "id", "region"))
+
+	assert.Equal(t, []jman.Difference{
+		{
+			Path:    "$.2",
+			Kind:    jman.DiffTypeMismatch,
+			Actual:  jman.Obj{"region": "eu"},
+			Message: "actual item has no key id, region",
+		},
+		{
+			Path:     `$[id=1,region="us"].active`,
+			Kind:     jman.DiffValueMismatch,
+			Expected: true,
+			Actual:   false,
+			Message:  "expected true - actual false",
+		},
+	}, diffs)
+}
+
+func TestDiff_ArrayKey_DuplicateKeys(t *testing.T) {
+	expected := jman.Arr{jman.Obj{"id": "a", "n": 1}, jman.Obj{"id": "a", "n": 2}}
+	actual := jman.Arr{jman.Obj{"id": "a", "n": 1}}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithArrayKey("$", "id"))
+
+	assert.Equal(t, []jman.Difference{{
+		Path:     `$[id="a"]`,
+		Kind:     jman.DiffMissing,
+		Expected: jman.Obj{"id": "a", "n": float64(2)},
+		Message:  "not found in actual",
+	}}, diffs)
+}
+
+func TestEqual_ArrayKey_InvalidOptions(t *testing.T) {
+	assertFatalf(t, "invalid options: path must start with $ or be a JSON pointer starting with /", func(mt jman.T) {
+		jman.Equal(mt, jman.Arr{}, jman.Arr{}, jman.WithArrayKey("users", "id"))
+	})
+	assertFatalf(t, "invalid options: array key for path '$.users' must name at least one non-empty field", func(mt jman.T) {
+		jman.Equal(mt, jman.Arr{}, jman.Arr{}, jman.WithArrayKey("$.users"))
+	})
+}
@@ -0,0 +1,105 @@
+package jman
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// canonical renders a normalized value as a string that is the same for values equal without any placeholders
+// or relaxing options, e.g. regardless of the order of object keys. Keys ignored with WithIgnoreKeys are left out.
+func canonical(v any, opts equalOptions) string {
+	var sb strings.Builder
+	writeCanonical(&sb, v, opts)
+	return sb.String()
+}
+
+func writeCanonical(sb *strings.Builder, v any, opts equalOptions) {
+	switch typed := v.(type) {
+	case nil:
+		sb.WriteString("null")
+	case bool:
+		sb.WriteString(strconv.FormatBool(typed))
+	case float64:
+		// -0 equals 0, but is formatted as "-0"
+		if typed == 0 {
+			typed = 0
+		}
+		sb.WriteString(strconv.FormatFloat(typed, 'g', -1, 64))
+	case json.Number:
+		// equal numbers can be written differently, e.g. 1.0 and 1e0
+		if r := exactValue(typed); r != nil {
+			sb.WriteString(r.RatString())
+		} else {
+			sb.WriteString(typed.String())
+		}
+	case string:
+		sb.WriteString(strconv.Quote(typed))
+	case Obj:
+		sb.WriteByte('{')
+		first := true
+		for _, k := range slices.Sorted(maps.Keys(typed)) {
+			if slices.Contains(opts.ignoreKeys, k) {
+				continue
+			}
+			if !first {
+				sb.WriteByte(',')
+			}
+			first = false
+			sb.WriteString(strconv.Quote(k))
+			sb.WriteByte(':')
+			writeCanonical(sb, typed[k], opts)
+		}
+		sb.WriteByte('}')
+	case Arr:
+		sb.WriteByte('[')
+		for i, item := range typed {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeCanonical(sb, item, opts)
+		}
+		sb.WriteByte(']')
+	default:
+		// not a normalized value, never exact, see equalOptions.exact
+		sb.WriteString(fmt.Sprintf("%T", v))
+	}
+}
+
+// exactKeys returns the sorted keys of an expected object whose values are exact at their location.
+func exactKeys(l *location, obj Obj, opts equalOptions) []string {
+	var keys []string
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		if !opts.ignored(l.child(k)) && opts.exact(l.child(k), obj[k]) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// signature renders the canonical values of the keys of an object, which are equal for any objects
+// with equal values for exact keys. Values that are not objects have an empty signature.
+func signature(v any, keys []string, opts equalOptions) string {
+	obj, ok := v.(Obj)
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for _, k := range keys {
+		sb.WriteString(strconv.Quote(k))
+		val, exists := obj[k]
+		if !exists {
+			sb.WriteString("!,")
+			continue
+		}
+		sb.WriteByte(':')
+		writeCanonical(&sb, val, opts)
+		sb.WriteByte(',')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
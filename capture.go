@@ -0,0 +1,111 @@
+package jman
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Capture creates a matcher that stores the actual value at its placeholder in dst, e.g. the generated id of
+// a created resource to use in the next request of a test. The value must also match all of the given matchers,
+// whose placeholders are not used, e.g. Capture("$ORDER_ID", &orderID, IsUUID("")).
+// Every occurrence of the placeholder in expected must have the same actual value, so that
+// {"id": "$ORDER_ID", "self": "$ORDER_ID"} fails if the two values differ.
+//
+// The value is converted to the type of dst through JSON if it is not of that type already, so an integer
+// can be captured into an int. A value that can't be converted doesn't match.
+// dst is set once the comparison is done, even if other values differ. It panics if dst is nil.
+func Capture[T any](placeholder string, dst *T, matchers ...Matcher) Matcher {
+	if dst == nil {
+		panic(fmt.Sprintf("jman: nil destination for capture %q", placeholder))
+	}
+	m := newMatcher(placeholder, func(v any) error {
+		if err := AllOf(placeholder, matchers...).Match(v); err != nil {
+			return err
+		}
+		_, err := captureValue[T](v)
+		return err
+	})
+	m.captures = []func(v any){func(v any) {
+		if val, err := captureValue[T](v); err == nil {
+			*dst = val
+		}
+	}}
+	return m
+}
+
+func captureValue[T any](v any) (T, error) {
+	if typed, ok := v.(T); ok {
+		return typed, nil
+	}
+	var val T
+	data, err := json.Marshal(v)
+	if err != nil {
+		return val, fmt.Errorf("can't capture: %w", err)
+	}
+	if err := json.Unmarshal(data, &val); err != nil {
+		return val, fmt.Errorf("can't capture: %w", err)
+	}
+	return val, nil
+}
+
+// captureState keeps the values captured during a comparison. A comparison that only tries whether two
+// values are equal, like pairing items of an array, works on a fork, so that what it captures is discarded
+// and the values are only captured once they are compared for the report.
+type captureState struct {
+	parent *captureState
+	values map[string]capturedValue
+}
+
+type capturedValue struct {
+	path   *location
+	value  any
+	stores []func(v any)
+}
+
+// fork returns a state that sees the values captured so far, but keeps the values it captures to itself.
+func (c *captureState) fork() *captureState {
+	if c == nil {
+		return nil
+	}
+	return &captureState{parent: c}
+}
+
+func (c *captureState) lookup(placeholder string) (capturedValue, bool) {
+	for s := c; s != nil; s = s.parent {
+		if captured, ok := s.values[placeholder]; ok {
+			return captured, true
+		}
+	}
+	return capturedValue{}, false
+}
+
+// record captures the actual value at the path for a placeholder. It returns an error if a different value
+// was captured for it before.
+func (c *captureState) record(path *location, placeholder string, actual any, stores []func(v any)) error {
+	if c == nil || len(stores) == 0 {
+		return nil
+	}
+	if captured, ok := c.lookup(placeholder); ok {
+		if !valuesEqual(captured.value, actual) {
+			return fmt.Errorf("captured %v at %s before", captured.value, captured.path)
+		}
+		return nil
+	}
+	if c.values == nil {
+		c.values = map[string]capturedValue{}
+	}
+	c.values[placeholder] = capturedValue{path: path, value: actual, stores: stores}
+	return nil
+}
+
+// store sets the destinations of the captured values.
+func (c *captureState) store() {
+	if c == nil {
+		return
+	}
+	for _, captured := range c.values {
+		for _, store := range captured.stores {
+			store(captured.value)
+		}
+	}
+}
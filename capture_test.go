@@ -0,0 +1,94 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_Capture(t *testing.T) {
+	var (
+		orderID string
+		total   int
+		order   jman.Obj
+	)
+	expected := `{"order": "$ORDER", "id": "$ORDER_ID", "total": "$TOTAL", "links": {"self": "$ORDER_ID"}}`
+	actual := `{"order": {"n": 1}, "id": "ord_1", "total": 42, "links": {"self": "ord_1"}}`
+
+	jman.Equal(t, expected, actual, jman.WithMatchers(
+		jman.Capture("$ORDER_ID", &orderID, jman.HasPrefix("", "ord_")),
+		jman.Capture("$TOTAL", &total),
+		jman.Capture("$ORDER", &order),
+	))
+
+	assert.Equal(t, "ord_1", orderID)
+	assert.Equal(t, 42, total)
+	assert.Equal(t, jman.Obj{"n": 1.0}, order)
+}
+
+func TestDiff_Capture_DifferentValues(t *testing.T) {
+	var orderID string
+	expected := jman.Obj{"order": jman.Obj{"id": "$ORDER_ID"}, "links": jman.Obj{"self": "$ORDER_ID"}}
+	actual := jman.Obj{"order": jman.Obj{"id": "ord_2"}, "links": jman.Obj{"self": "ord_1"}}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithMatchers(jman.Capture("$ORDER_ID", &orderID)))
+
+	assert.Equal(t, []string{
+		`$.order.id expected value for placeholder "$ORDER_ID" does not match actual value ord_2: captured ord_1 at $.links.self before`,
+	}, diffStrings(diffs))
+	assert.Equal(t, "ord_1", orderID)
+}
+
+func TestDiff_Capture_Reasons(t *testing.T) {
+	var (
+		id    string
+		count int
+	)
+	matchers := jman.WithMatchers(jman.Capture("$ID", &id, jman.IsUUID("")), jman.Capture("$COUNT", &count))
+
+	diffs := jman.Diff(t, jman.Obj{"id": "$ID", "count": "$COUNT"}, jman.Obj{"id": "x", "count": 1.5}, matchers)
+
+	assert.ElementsMatch(t, []string{
+		`$.id expected value for placeholder "$ID" does not match actual value x: not a UUID (length 1 instead of 36)`,
+		`$.count expected value for placeholder "$COUNT" does not match actual value 1.5: can't capture: json: cannot unmarshal number 1.5 into Go value of type int`,
+	}, diffStrings(diffs))
+	assert.Empty(t, id)
+	assert.Zero(t, count)
+}
+
+func TestEqual_Capture_TrialsDoNotCapture(t *testing.T) {
+	var id string
+	capture := jman.WithMatchers(jman.Capture("$ID", &id))
+
+	jman.Equal(t, jman.Arr{"$ID", "b", "$ID"}, jman.Arr{"a", "b", "a"}, capture)
+	assert.Equal(t, "a", id)
+
+	expected := jman.Arr{jman.Obj{"kind": "main", "id": "$ID"}, jman.Obj{"kind": "copy", "of": "$ID"}}
+	actual := jman.Arr{jman.Obj{"kind": "copy", "of": "c"}, jman.Obj{"kind": "other", "id": "x"}, jman.Obj{"kind": "main", "id": "c"}}
+	diffs := jman.Diff(t, expected, actual, capture, jman.WithIgnoreArrayOrder("$"))
+	assert.Equal(t, []string{"$ expected 2 items - got 3 items"}, diffStrings(diffs))
+	assert.Equal(t, "c", id)
+}
+
+func TestDiff_Capture_IgnoreOrder_DifferentValues(t *testing.T) {
+	var id string
+
+	diffs := jman.Diff(t, jman.Arr{"$ID", "$ID"}, jman.Arr{"a", "b"}, jman.WithMatchers(jman.Capture("$ID", &id)), jman.WithIgnoreArrayOrder("$"))
+
+	assert.Equal(t, []string{
+		`$.1 expected value for placeholder "$ID" does not match actual value b: captured a at $.0 before`,
+	}, diffStrings(diffs))
+}
+
+func TestCapture_CombinedWithMatcher(t *testing.T) {
+	var id string
+	matchers := jman.Matchers{jman.IsUUID("$ID"), jman.Capture("$ID", &id)}
+
+	jman.Equal(t, `{"id": "$ID"}`, `{"id": "550e8400-e29b-41d4-a716-446655440000"}`, jman.WithDefaultMatchers(matchers))
+
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", id)
+	assert.PanicsWithValue(t, `jman: nil destination for capture "$ID"`, func() {
+		jman.Capture[string]("$ID", nil)
+	})
+}
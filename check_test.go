@@ -0,0 +1,49 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_Equal(t *testing.T) {
+	ok := jman.Check(t, jman.Obj{"a": 1}, `{"a":1}`)
+	assert.True(t, ok)
+}
+
+func TestCheck_ReportsEveryFailure(t *testing.T) {
+	mt := newSoftMockT()
+
+	first := jman.Check(mt, jman.Obj{"a": 1}, `{"a":2}`)
+	second := jman.Check(mt, jman.Arr{"x"}, `["y"]`)
+
+	assert.False(t, first)
+	assert.False(t, second)
+	assert.Equal(t, []string{
+		"expected not equal to actual:\nexpected {\"a\":1}\nactual {\"a\":2}\n\n$.a expected 1 - actual 2\n",
+		"expected not equal to actual:\nexpected [\"x\"]\nactual [\"y\"]\n\n$.0 expected \"x\" - actual \"y\"\n",
+	}, mt.errors)
+	assert.Empty(t, mt.unmarked)
+}
+
+func TestCheck_InvalidInputIsSoft(t *testing.T) {
+	mt := newSoftMockT()
+
+	ok := jman.Check(mt, jman.Obj{"a": 1}, jman.Arr{})
+
+	assert.False(t, ok)
+	assert.Equal(t, []string{"can't compare json object with array"}, mt.errors)
+	assert.Empty(t, mt.unmarked)
+}
+
+func TestCheck_FallsBackToFatalf(t *testing.T) {
+	assertFatalf(t, `expected not equal to actual:
+expected {"a":1}
+actual {"a":2}
+
+$.a expected 1 - actual 2
+`, func(mt jman.T) {
+		jman.Check(mt, jman.Obj{"a": 1}, `{"a":2}`)
+	})
+}
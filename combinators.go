@@ -0,0 +1,79 @@
+package jman
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// AllOf creates a matcher that checks that the value matches all of the given matchers,
+// e.g. AllOf("$ID", IsUUID(""), NotEmpty("")). The placeholders of the given matchers are not used,
+// so they can be empty. The reason a value doesn't match lists the reasons of every matcher it fails.
+func AllOf(placeholder string, matchers ...Matcher) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		var failed []error
+		for _, m := range matchers {
+			if err := m.Match(v); err != nil {
+				failed = append(failed, err)
+			}
+		}
+		if len(failed) == 1 {
+			return failed[0]
+		}
+		if len(failed) > 1 {
+			return errors.New(joinReasons(failed))
+		}
+		return nil
+	})
+}
+
+// AnyOf creates a matcher that checks that the value matches at least one of the given matchers,
+// e.g. AnyOf("$ID", IsUUID(""), IsULID("")). The placeholders of the given matchers are not used,
+// so they can be empty. The reason a value doesn't match lists the reasons of every matcher.
+func AnyOf(placeholder string, matchers ...Matcher) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		failed := make([]error, 0, len(matchers))
+		for _, m := range matchers {
+			err := m.Match(v)
+			if err == nil {
+				return nil
+			}
+			failed = append(failed, err)
+		}
+		return errors.New("none of the matchers match: " + joinReasons(failed))
+	})
+}
+
+// Not creates a matcher that checks that the value doesn't match the given matcher,
+// e.g. Not("$OTHER_ID", EqualMatcher("", "42")). The placeholder of the given matcher is not used.
+func Not(placeholder string, matcher Matcher) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		if matcher.Match(v) == nil {
+			return errors.New("matches, but should not")
+		}
+		return nil
+	})
+}
+
+// Nullable creates a matcher with the placeholder of the given matcher that accepts null or any
+// value the given matcher accepts, e.g. Nullable(IsUUID("$ID")) for "a UUID or null".
+func Nullable(matcher Matcher) Matcher {
+	return newMatcher(matcher.Placeholder, func(v any) error {
+		if v == nil {
+			return nil
+		}
+		return matcher.Match(v)
+	})
+}
+
+// joinReasons joins the reasons matchers give for a value not matching, separated by semicolons.
+// A reason given by several matchers, like "number instead of string", is only included once.
+func joinReasons(errs []error) string {
+	reasons := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if !slices.Contains(reasons, err.Error()) {
+			reasons = append(reasons, err.Error())
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
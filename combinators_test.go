@@ -0,0 +1,68 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinators(t *testing.T) {
+	const uuid = "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := map[string]struct {
+		matcher jman.Matcher
+		value   any
+		reason  string
+	}{
+		"all of":                {matcher: jman.AllOf("$ID", jman.IsUUID(""), jman.NotEmpty("")), value: uuid},
+		"all of one fails":      {matcher: jman.AllOf("$ID", jman.IsString(""), jman.HasPrefix("", "ord_")), value: "inv_1", reason: `does not start with "ord_"`},
+		"all of several fail":   {matcher: jman.AllOf("$ID", jman.IsUUID(""), jman.NotEmpty("")), value: "", reason: "not a UUID (length 0 instead of 36); empty string"},
+		"any of":                {matcher: jman.AnyOf("$ID", jman.IsUUID(""), jman.IsULID("")), value: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		"any of none":           {matcher: jman.AnyOf("$ID", jman.IsUUID(""), jman.IsNull("")), value: 1.0, reason: "none of the matchers match: not a UUID (number instead of string); number instead of null"},
+		"not":                   {matcher: jman.Not("$OTHER", jman.EqualMatcher("", "a")), value: "b"},
+		"not matching":          {matcher: jman.Not("$OTHER", jman.EqualMatcher("", "a")), value: "a", reason: "matches, but should not"},
+		"nullable null":         {matcher: jman.Nullable(jman.IsUUID("$ID")), value: nil},
+		"nullable value":        {matcher: jman.Nullable(jman.IsUUID("$ID")), value: uuid},
+		"nullable non-matching": {matcher: jman.Nullable(jman.IsUUID("$ID")), value: "x", reason: "not a UUID (length 1 instead of 36)"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.matcher.Match(tt.value)
+
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.reason)
+		})
+	}
+}
+
+func TestEqual_Combinators(t *testing.T) {
+	expected := `{"id": "$ID", "parent_id": "$PARENT_ID"}`
+	actual := `{"id": "550e8400-e29b-41d4-a716-446655440000", "parent_id": null}`
+
+	jman.Equal(t, expected, actual, jman.WithMatchers(
+		jman.AllOf("$ID", jman.IsUUID(""), jman.NotEmpty("")),
+		jman.Nullable(jman.IsUUID("$PARENT_ID")),
+	))
+}
+
+func TestFindByPlaceholder_CombinesMatchers(t *testing.T) {
+	matchers := jman.Matchers{jman.IsString("$ID"), jman.HasPrefix("$ID", "ord_"), jman.NotEmpty("")}
+
+	m, found := matchers.FindByPlaceholder("$ID")
+	assert.True(t, found)
+	assert.NoError(t, m.Match("ord_1"))
+	assert.EqualError(t, m.Match("inv_1"), `does not start with "ord_"`)
+
+	_, found = matchers.FindByPlaceholder("")
+	assert.False(t, found)
+
+	diffs := jman.Diff(t, jman.Obj{"id": "$ID"}, jman.Obj{"id": 1}, jman.WithMatchers(matchers...))
+	assert.Equal(t, []string{
+		`$.id expected value for placeholder "$ID" does not match actual value 1: number instead of string`,
+	}, diffStrings(diffs))
+}
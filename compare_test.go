@@ -0,0 +1,213 @@
+package jman_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare_Equal(t *testing.T) {
+	report, err := jman.Compare(`{"a":1,"b":[true]}`, jman.Obj{"b": jman.Arr{true}, "a": 1})
+	assert.NoError(t, err)
+
+	assert.True(t, report.Equal())
+	assert.Empty(t, report.Differences)
+	assert.Empty(t, report.String())
+	assert.Equal(t, jman.Obj{"a": float64(1), "b": jman.Arr{true}}, report.Expected)
+}
+
+func TestCompare_NotEqual(t *testing.T) {
+	report, err := jman.Compare(jman.Obj{"a": 1}, `{"a":2}`)
+	assert.NoError(t, err)
+
+	assert.False(t, report.Equal())
+	assert.Equal(t, []jman.Difference{{
+		Path:     "$.a",
+		Kind:     jman.DiffValueMismatch,
+		Expected: float64(1),
+		Actual:   float64(2),
+		Message:  "expected 1 - actual 2",
+	}}, report.Differences)
+	assert.Equal(t, "expected not equal to actual:\nexpected {\"a\":1}\nactual {\"a\":2}\n\n$.a expected 1 - actual 2\n", report.String())
+}
+
+func TestCompare_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected any
+		actual   any
+		err      string
+	}{
+		{
+			name:     "invalid json",
+			expected: `{"a":`,
+			actual:   jman.Obj{},
+			err:      "error parsing JSON data",
+		},
+		{
+			name:     "object with array",
+			expected: jman.Obj{},
+			actual:   jman.Arr{},
+			err:      "can't compare json object with array",
+		},
+		{
+			name:     "unsupported type",
+			expected: make(chan int),
+			actual:   jman.Arr{},
+			err:      "unsupported type for JSON data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := jman.Compare(tt.expected, tt.actual)
+			assert.Nil(t, report)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestCompare_InvalidOptions(t *testing.T) {
+	_, err := jman.Compare(jman.Arr{}, jman.Arr{}, jman.WithIgnoreArrayOrder("items"))
+	assert.ErrorContains(t, err, "invalid options")
+}
+
+func TestNewE(t *testing.T) {
+	obj, err := jman.NewE[jman.Obj](`{"a":[1,{"b":null}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, jman.Obj{"a": jman.Arr{float64(1), jman.Obj{"b": nil}}}, obj)
+
+	_, err = jman.NewE[jman.Arr](`{"a":1}`)
+	assert.ErrorIs(t, err, jman.ErrJSONParse)
+
+	_, err = jman.NewE[jman.Obj](42)
+	assert.ErrorIs(t, err, jman.ErrUnsupportedType)
+}
+
+func TestNewFromFileE(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arr.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[1,"two"]`), 0o600))
+
+	arr, err := jman.NewFromFileE[jman.Arr](path)
+	assert.NoError(t, err)
+	assert.Equal(t, jman.Arr{float64(1), "two"}, arr)
+
+	_, err = jman.NewFromFileE[jman.Arr](filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, jman.ErrJSONRead)
+}
+
+func TestObj_GetE(t *testing.T) {
+	obj := jman.Obj{"users": jman.Arr{jman.Obj{"id": 1}, jman.Obj{"id": 2}}}
+
+	val, err := obj.GetE("$.users.1.id")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), val)
+
+	_, err = obj.GetE("$.users.5.id")
+	assert.EqualError(t, err, "failed to get value at path '$.users.5.id': index 5 out of bounds for array of length 2")
+
+	vals, err := obj.GetAllE("$.users[*].id")
+	assert.NoError(t, err)
+	assert.Equal(t, jman.Arr{float64(1), float64(2)}, vals)
+
+	_, err = obj.GetAllE("users")
+	assert.ErrorContains(t, err, "failed to get values at path 'users'")
+}
+
+func TestArr_GetE(t *testing.T) {
+	arr := jman.Arr{jman.Obj{"name": "a"}}
+
+	val, err := arr.GetE("/0/name")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", val)
+
+	_, err = arr.GetE("$.0.missing")
+	assert.EqualError(t, err, "failed to get value at path '$.0.missing': key 'missing' not found in object")
+}
+
+func TestObj_SetE(t *testing.T) {
+	obj := jman.Obj{}
+
+	assert.NoError(t, obj.SetE("$.user.tags", []string{"a"}))
+	assert.Equal(t, jman.Obj{"user": jman.Obj{"tags": jman.Arr{"a"}}}, obj)
+
+	assert.Error(t, obj.SetE("$", 1))
+}
+
+func TestArr_SetEAndDeleteE(t *testing.T) {
+	arr := jman.Arr{1, 2, 3}
+
+	assert.NoError(t, arr.SetE("$.0", "one"))
+	assert.NoError(t, arr.DeleteE("$.1"))
+	assert.Equal(t, jman.Arr{"one", float64(3)}, arr)
+
+	assert.Error(t, arr.DeleteE("$.5"))
+}
+
+func TestObj_DeleteE(t *testing.T) {
+	obj := jman.Obj{"a": 1, "b": 2}
+
+	assert.NoError(t, obj.DeleteE("$.a"))
+	assert.Equal(t, jman.Obj{"b": float64(2)}, obj)
+
+	assert.EqualError(t, obj.DeleteE("$.a"), "key 'a' not found in object")
+}
+
+func TestObj_AppendEAndInsertE(t *testing.T) {
+	obj := jman.Obj{"tags": jman.Arr{"b"}}
+
+	assert.NoError(t, obj.AppendE("$.tags", "c"))
+	assert.NoError(t, obj.InsertE("$.tags", 0, "a"))
+	assert.Equal(t, jman.Obj{"tags": jman.Arr{"a", "b", "c"}}, obj)
+
+	assert.EqualError(t, obj.InsertE("$.tags", 9, "x"), "index 9 out of bounds for array of length 3")
+}
+
+func TestArr_AppendEAndInsertE(t *testing.T) {
+	arr := jman.Arr{2}
+
+	assert.NoError(t, arr.AppendE("$", 3))
+	assert.NoError(t, arr.InsertE("$", 0, 1))
+	assert.Equal(t, jman.Arr{float64(1), float64(2), float64(3)}, arr)
+
+	assert.Error(t, arr.InsertE("$", -1, 0))
+}
+
+func TestApplyPatchEAndPatchE(t *testing.T) {
+	from := jman.Obj{"a": 1, "b": jman.Arr{1}}
+	to := jman.Obj{"a": 2, "c": true}
+
+	patch, err := jman.PatchE(from, to)
+	assert.NoError(t, err)
+	assert.NoError(t, from.ApplyPatchE(patch))
+	assert.Equal(t, jman.Obj{"a": float64(2), "c": true}, from)
+
+	arr := jman.Arr{1}
+	assert.NoError(t, arr.ApplyPatchE(jman.Arr{jman.Obj{"op": "add", "path": "/-", "value": 2}}))
+	assert.Equal(t, jman.Arr{float64(1), float64(2)}, arr)
+
+	err = from.ApplyPatchE(jman.Arr{jman.Obj{"op": "test", "path": "/a", "value": 3}, jman.Obj{"op": "remove", "path": "/a"}})
+	assert.EqualError(t, err, "patch operation 0 (test /a) failed: test failed:\n$.a expected 3 - actual 2\n")
+	assert.Equal(t, jman.Obj{"a": float64(2), "c": true}, from)
+
+	_, err = jman.PatchE("nope", jman.Obj{})
+	assert.Error(t, err)
+}
+
+func TestMergeEAndDeepMergeE(t *testing.T) {
+	obj := jman.Obj{"a": 1, "b": 2}
+
+	assert.NoError(t, obj.MergeE(`{"b": null, "c": 3}`))
+	assert.Equal(t, jman.Obj{"a": float64(1), "c": float64(3)}, obj)
+	assert.Error(t, obj.MergeE(`[1]`))
+
+	merged, err := jman.DeepMergeE(`{"a": {"x": 1}}`, jman.Obj{"a": jman.Obj{"y": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, jman.Obj{"a": jman.Obj{"x": float64(1), "y": float64(2)}}, merged)
+
+	_, err = jman.DeepMergeE(`{}`, "nope")
+	assert.Error(t, err)
+}
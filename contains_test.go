@@ -0,0 +1,77 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+)
+
+func TestContains(t *testing.T) {
+	expected := jman.Obj{
+		"id":    "$UUID",
+		"owner": jman.Obj{"name": "alice"},
+		"items": jman.Arr{jman.Obj{"sku": "a"}, jman.Obj{"sku": "b"}},
+	}
+	actual := `{
+		"id": "9b74c989-7cdf-41fa-9a49-5290f31e59d3",
+		"createdAt": "2024-01-01",
+		"owner": {"name": "alice", "email": "alice@example.com"},
+		"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]
+	}`
+
+	jman.Contains(t, expected, actual, jman.WithMatchers(jman.IsUUID("$UUID")))
+}
+
+func TestContains_Unequal(t *testing.T) {
+	expected := jman.Obj{
+		"owner": jman.Obj{"name": "alice", "email": "alice@example.com"},
+		"items": jman.Arr{jman.Obj{"sku": "a"}},
+	}
+	actual := jman.Obj{
+		"extra": true,
+		"owner": jman.Obj{"name": "bob"},
+		"items": jman.Arr{jman.Obj{"sku": "a"}, jman.Obj{"sku": "b"}},
+	}
+
+	expectedMsg := `expected not equal to actual:
+expected {"items":[{"sku":"a"}],"owner":{"email":"alice@example.com","name":"alice"}}
+actual {"extra":true,"items":[{"sku":"a"},{"sku":"b"}],"owner":{"name":"bob"}}
+
+$.owner.email not found in actual
+$.owner.name expected "alice" - actual "bob"
+$.items expected 1 items - got 2 items
+$.items.1 {"sku":"b"} inserted at index 1
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Contains(mt, expected, actual)
+	})
+}
+
+func TestEqual_AllowExtraKeysAt(t *testing.T) {
+	expected := jman.Obj{
+		"meta": jman.Obj{"page": jman.Obj{"size": 10}},
+		"data": jman.Obj{"id": 1},
+	}
+	actual := jman.Obj{
+		"meta": jman.Obj{"requestId": "abc", "page": jman.Obj{"size": 10, "cursor": "xyz"}},
+		"data": jman.Obj{"id": 1, "name": "alice"},
+	}
+
+	jman.Equal(t, expected, actual, jman.WithAllowExtraKeysAt("$.meta", "/data"))
+
+	expectedMsg := `expected not equal to actual:
+expected {"data":{"id":1},"meta":{"page":{"size":10}}}
+actual {"data":{"id":1,"name":"alice"},"meta":{"page":{"cursor":"xyz","size":10},"requestId":"abc"}}
+
+$.data.name unexpected key
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, expected, actual, jman.WithAllowExtraKeysAt("$.meta"))
+	})
+}
+
+func TestEqual_AllowExtraKeysAt_InvalidPath(t *testing.T) {
+	assertFatalf(t, "invalid options: path must start with $ or be a JSON pointer starting with /", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithAllowExtraKeysAt("meta"))
+	})
+}
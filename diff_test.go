@@ -0,0 +1,98 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_Equal(t *testing.T) {
+	diffs := jman.Diff(t, jman.Obj{"a": 1}, `{"a":1}`)
+	assert.Empty(t, diffs)
+}
+
+func TestDiff_Kinds(t *testing.T) {
+	expected := jman.Obj{
+		"missing": "value",
+		"type":    "string",
+		"value":   1,
+		"items":   jman.Arr{1, 2},
+		"id":      "$UUID",
+	}
+	actual := jman.Obj{
+		"unexpected": true,
+		"type":       1,
+		"value":      2,
+		"items":      jman.Arr{1},
+		"id":         "not-a-uuid",
+	}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithMatchers(jman.IsUUID("$UUID")))
+
+	assert.ElementsMatch(t, []jman.Difference{
+		{
+			Path:     "$.missing",
+			Kind:     jman.DiffMissing,
+			Expected: "value",
+			Message:  "not found in actual",
+		},
+		{
+			Path:    "$.unexpected",
+			Kind:    jman.DiffUnexpected,
+			Actual:  true,
+			Message: "unexpected key",
+		},
+		{
+			Path:     "$.type",
+			Kind:     jman.DiffTypeMismatch,
+			Expected: "string",
+			Actual:   float64(1),
+			Message:  `expected "string" - actual 1`,
+		},
+		{
+			Path:     "$.value",
+			Kind:     jman.DiffValueMismatch,
+			Expected: float64(1),
+			Actual:   float64(2),
+			Message:  "expected 1 - actual 2",
+		},
+		{
+			Path:     "$.items",
+			Kind:     jman.DiffLengthMismatch,
+			Expected: jman.Arr{float64(1), float64(2)},
+			Actual:   jman.Arr{float64(1)},
+			Message:  "expected 2 items - got 1 items",
+		},
+		{
+			Path:     "$.items.1",
+			Kind:     jman.DiffMissing,
+			Expected: float64(2),
+			Message:  "2 removed from index 1",
+		},
+		{
+			Path:     "$.id",
+			Kind:     jman.DiffMatcherFailed,
+			Expected: "$UUID",
+			Actual:   "not-a-uuid",
+			Message:  `expected value for placeholder "$UUID" does not match actual value not-a-uuid: not a UUID (length 10 instead of 36)`,
+		},
+	}, diffs)
+}
+
+func TestDiff_NestedPaths(t *testing.T) {
+	expected := jman.Arr{jman.Obj{"a": jman.Arr{"x", "y"}}}
+	actual := jman.Arr{jman.Obj{"a": jman.Arr{"x", "z"}}}
+
+	diffs := jman.Diff(t, expected, actual)
+
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "$.0.a.1", diffs[0].Path)
+	assert.Equal(t, `$.0.a.1 expected "y" - actual "z"`, diffs[0].String())
+}
+
+func TestDiff_InvalidOptions(t *testing.T) {
+	assertFatalf(t, "invalid options: path must start with $ or be a JSON pointer starting with /", func(mt jman.T) {
+		jman.Diff(mt, jman.Arr{}, jman.Arr{}, jman.WithIgnoreArrayOrder("items"))
+	})
+}
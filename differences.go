@@ -1,9 +1,84 @@
 package jman
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
+// DiffKind describes why a value in actual differs from expected.
+type DiffKind string
+
+const (
+	// DiffMissing is a key or array item in expected that is not in actual.
+	DiffMissing DiffKind = "missing"
+	// DiffUnexpected is a key in actual that is not in expected.
+	DiffUnexpected DiffKind = "unexpected"
+	// DiffTypeMismatch is a value in actual with a different JSON type than expected.
+	DiffTypeMismatch DiffKind = "type mismatch"
+	// DiffValueMismatch is a value in actual of the same JSON type but a different value than expected.
+	DiffValueMismatch DiffKind = "value mismatch"
+	// DiffLengthMismatch is an array in actual with a different number of items than expected.
+	DiffLengthMismatch DiffKind = "length mismatch"
+	// DiffMatcherFailed is a value in actual rejected by the matcher for the placeholder in expected.
+	DiffMatcherFailed DiffKind = "matcher failed"
+)
+
+// Difference is a single difference between an expected and actual JSON value, as returned by Diff.
+type Difference struct {
+	// Path is the location of the difference, e.g. $.users.0.email.
+	Path string
+	Kind DiffKind
+	// Expected is the expected value at Path, nil for DiffUnexpected.
+	Expected any
+	// Actual is the actual value at Path, nil for DiffMissing.
+	Actual any
+	// Message describes the difference as it appears in the report of Equal.
+	Message string
+}
+
+// String returns the difference as it appears in the report of Equal.
+func (d Difference) String() string {
+	return fmt.Sprintf("%s %s", d.Path, d.Message)
+}
+
+// DiffReport is the result of comparing two JSON values with Compare.
+type DiffReport struct {
+	// Expected and Actual are the compared values, normalized to Obj or Arr, or for a scalar or null root
+	// to the Value of a Val, e.g. "ok", float64(42) or nil.
+	// They may share nested values with the values passed to Compare.
+	Expected any
+	Actual   any
+	// Differences holds every difference found, empty if the values are equal.
+	Differences []Difference
+
+	diffs differences
+}
+
+func newDiffReport(expected, actual any, diffs differences) *DiffReport {
+	return &DiffReport{
+		Expected:    expected,
+		Actual:      actual,
+		Differences: diffs.flatten(),
+		diffs:       diffs,
+	}
+}
+
+// Equal reports whether the compared values are equal.
+func (r *DiffReport) Equal() bool {
+	return len(r.diffs) == 0
+}
+
+// String returns the report Equal fails with, or an empty string if the values are equal.
+func (r *DiffReport) String() string {
+	if r.Equal() {
+		return ""
+	}
+	// both values are normalized, so marshaling can't fail
+	expectedJSON, _ := json.Marshal(r.Expected)
+	actualJSON, _ := json.Marshal(r.Actual)
+	return fmt.Sprintf("expected not equal to actual:\nexpected %s\nactual %s\n\n%s", expectedJSON, actualJSON, r.diffs.report())
+}
+
 type differences []difference
 
 func (d differences) report() string {
@@ -18,25 +93,35 @@ func (d differences) report() string {
 	return report
 }
 
-func (d differences) hasPath(path string) bool {
-	for _, d := range d {
-		if d.path == path {
-			return true
+// flatten returns the differences at the leaves of the tree in report order.
+func (d differences) flatten() []Difference {
+	var flat []Difference
+	for _, diff := range d {
+		if diff.diff == "" {
+			flat = append(flat, diff.subDiffs.flatten()...)
+			continue
 		}
+		flat = append(flat, Difference{
+			Path:     diff.path.String(),
+			Kind:     diff.kind,
+			Expected: diff.expected,
+			Actual:   diff.actual,
+			Message:  diff.diff,
+		})
 	}
-
-	return false
+	return flat
 }
 
 type difference struct {
-	diff     string
-	path     string
+	diff string
+	// path is rendered only when reported, since most differences of trial comparisons never are
+	path     *location
+	kind     DiffKind
+	expected any
+	actual   any
 	subDiffs differences
 }
 
 func (d difference) String() string {
-	if d.path[:2] != "$." {
-		return fmt.Sprintf("%s.%s %s", base, d.path, d.diff)
-	}
 	return fmt.Sprintf("%s %s", d.path, d.diff)
 }
@@ -5,9 +5,16 @@
 //
 //   • Obj — JSON object implemented as `map[string]any`.
 //   • Arr — JSON array implemented as `[]any`.
+//   • Val — JSON document with a scalar or null root, e.g. "ok", 42 or null.
 //
 // # Testing Interface
 //   • T — interface for testing, e.g. `*testing.T`. Only Implements Fatalf() method.
+//     Check reports with Errorf() instead when the T has one, and every function calls
+//     Helper() when available so failures point at the test rather than jman.
+//
+// Outside of tests, use the error-returning counterparts the T functions are built on. Each is
+// named after its T function with an E suffix, e.g. GetE, SetE and NewE, except Compare, which
+// returns the report of Equal, Check and Diff.
 //
 // Both types satisfy the JSONEqual interface and can be created:
 //
@@ -27,6 +34,12 @@
 //   user.Set(t, "$.settings.theme", "dark")
 //   tags.Set(t, "$.1", "unit")
 //
+// Paths also accept JSONPath wildcards, recursive descent, slices and filters.
+// GetAll returns every matching value:
+//
+//   ids := users.GetAll(t, "$[*].id")
+//   active := users.GetAll(t, `$[?(@.status=="active")]`)
+//
 // There are diffferent getters for each type expected to be returned
 //
 // Setter can set any type as a value, however the value will be normalized into either:
@@ -71,6 +84,13 @@
 //   $.name expected "alice" - got "bob"
 //   $.extra unexpected key
 //
+// # Golden Files
+//
+// MatchGolden compares a value against a JSON file, rewriting the file instead when the
+// tests run with -jman.update or JMAN_UPDATE=1:
+//
+//   jman.MatchGolden(t, "testdata/user.json", body, jman.WithMatchers(jman.IsUUID("$UUID")))
+//
 // # Matchers
 //
 // Matchers allow placeholders in the *expected* JSON that are resolved
@@ -80,12 +100,39 @@
 //   jman.NotEmpty("{{nonEmpty}}")    // non-empty string/array/object
 //   jman.EqualMatcher("{{id}}", 99)  // equals specific value
 //
-// Write your own with `jman.Custom`. A placeholder is a string that when found in the expected as a value,
+// Further built-in matchers check JSON types (IsString, IsNumber, IsInteger, ...), ranges
+// (InRange, LenBetween), strings (HasPrefix, MatchesRegex) and common formats (IsRFC3339,
+// IsDate, IsEmail, IsURL, IsIPv4, IsIPv6, IsSemver, IsCurrencyCode, IsHex, IsBase64, IsULID, IsKSUID).
+//
+// Combine matchers with AllOf, AnyOf, Not and Nullable, e.g. jman.Nullable(jman.IsUUID("$ID")).
+// Several matchers with the same placeholder must all match.
+//
+// Capture stores the actual value of a placeholder for later steps of a test, and requires
+// every occurrence of the placeholder to have the same value:
+//
+//   var orderID string
+//   jman.Equal(t, expected, body, jman.WithMatchers(jman.Capture("$ORDER_ID", &orderID)))
+//
+// Write your own with `jman.Custom`, or with `jman.CustomMatch` to return the reason a value doesn't match,
+// which is shown in the difference. A placeholder is a string that when found in the expected as a value,
 // will find the corresponding value in the actual JSON and compare it using the matcher.
 //
+// Placeholders can take an argument, e.g. "$regex(^ord_)", "$len(3)", "$gt(10)" or "$oneOf(a,b)".
+// Add more with RegisterParamMatcher, or WithParamMatcher for a single comparison.
+//
 // # Options
 //
 //   • WithIgnoreArrayOrder(paths...) — compare arrays as sets for given paths.
+//   • WithArrayKey(path, fields...) — pair array items by key fields, e.g. $.users[id=42].
 //   • WithDefaultMatchers(ms)       — register Matchers once per comparison.
+//   • WithAllowExtraKeys()          — allow keys in actual that are not in expected;
+//     Contains is Equal with this option.
+//   • WithAllowExtraKeysAt(paths...) — allow extra keys only in the objects at the given paths.
+//   • WithIgnorePaths(paths...)     — leave the keys at the given paths out of the comparison.
+//   • WithIgnoreKeys(names...)      — leave keys with the given names out at any depth.
+//   • WithFloatTolerance(epsilon)   — allow numbers to differ by up to epsilon; WithFloatToleranceAt,
+//     WithRelativeFloatTolerance and WithRelativeFloatToleranceAt narrow it to a path or scale it.
+//   • WithUseNumber()               — keep numbers as json.Number to compare large integers exactly;
+//     also accepted by New and NewFromFile.
 //
 package jman
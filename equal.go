@@ -26,128 +26,275 @@ type JSONEqual interface {
 // Equal compares two JSON values where each value can be Obj, Arr, JSON string/bytes,
 // or any value that can be marshaled into a JSON object/array.
 func Equal(t T, expected, actual any, optFuncs ...optsFunc) {
-	expectedVal, expectedIsObj := normalizeComparable(t, expected)
-	actualVal, actualIsObj := normalizeComparable(t, actual)
-
-	if expectedIsObj != actualIsObj {
-		if expectedIsObj {
-			t.Fatalf("can't compare json object with array")
-			return
-		}
-		t.Fatalf("can't compare array with json object")
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	report, err := Compare(expected, actual, optFuncs...)
+	if err != nil {
+		t.Fatalf(err.Error())
 		return
 	}
+	if !report.Equal() {
+		t.Fatalf(report.String())
+	}
+}
 
-	if expectedIsObj {
-		expectedVal.(Obj).Equal(t, actualVal, optFuncs...)
-		return
+// Contains compares two JSON values like Equal, but allows keys in actual objects that are not in expected,
+// so that expected only has to list the keys a test cares about. It is Equal with WithAllowExtraKeys.
+func Contains(t T, expected, actual any, optFuncs ...optsFunc) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	Equal(t, expected, actual, append(optFuncs, WithAllowExtraKeys())...)
+}
+
+// Check compares two JSON values like Equal, but reports a failure with t.Errorf when T has an
+// Errorf method, as *testing.T does, so that the test carries on and later checks still report.
+// It returns whether the values are equal. If T has no Errorf method, it fails with t.Fatalf.
+func Check(t T, expected, actual any, optFuncs ...optsFunc) bool {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	report, err := Compare(expected, actual, optFuncs...)
+	if err != nil {
+		errorf(t, err.Error())
+		return false
+	}
+	if !report.Equal() {
+		errorf(t, report.String())
+		return false
+	}
+	return true
+}
+
+// Diff compares two JSON values like Equal, but instead of failing it returns every difference found.
+// It is empty if the values are equal. It still fails if either value or the options are invalid.
+func Diff(t T, expected, actual any, optFuncs ...optsFunc) []Difference {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	report, err := Compare(expected, actual, optFuncs...)
+	if err != nil {
+		t.Fatalf(err.Error())
+		return nil
+	}
+	return report.Differences
+}
+
+// Compare compares two JSON values like Equal and returns a report of their differences.
+// Rather than failing a test, it returns an error if either value or the options are invalid,
+// so it can be used outside of tests. Values that differ are not an error, see DiffReport.Equal.
+func Compare(expected, actual any, optFuncs ...optsFunc) (*DiffReport, error) {
+	opts := newEqualOptions(optFuncs)
+	if err := opts.valid(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
+	expectedVal, expectedKind, err := normalizeComparable(expected, opts)
+	if err != nil {
+		return nil, err
+	}
+	actualVal, actualKind, err := normalizeComparable(actual, opts)
+	if err != nil {
+		return nil, err
 	}
-	expectedVal.(Arr).Equal(t, actualVal, optFuncs...)
+
+	var diffs differences
+	switch {
+	case expectedKind == objectRoot && actualKind == objectRoot:
+		diffs = compareObjects(nil, expectedVal.(Obj), actualVal.(Obj), opts)
+	case expectedKind == arrayRoot && actualKind == arrayRoot:
+		diffs = compareArrays(nil, expectedVal.(Arr), actualVal.(Arr), opts)
+	case expectedKind == objectRoot && actualKind == arrayRoot:
+		return nil, errors.New("can't compare json object with array")
+	case expectedKind == arrayRoot && actualKind == objectRoot:
+		return nil, errors.New("can't compare array with json object")
+	default:
+		// a scalar root is compared like any value, so a placeholder can match it and an object or array differs from it
+		if equal, diff := compareValues(nil, expectedVal, actualVal, opts); !equal {
+			diffs = differences{diff}
+		}
+	}
+	opts.captures.store()
+	return newDiffReport(expectedVal, actualVal, diffs), nil
 }
 
 // New creates a new instance of type T from the provided data.
 // The data can be a JSON string, a byte slice, or an instance of type T.
 // It fails the test via t.Fatalf if data cannot be parsed or normalized into type T.
-func New[E JSONEqual](t T, data any) E {
-	var result E
+// Of the options, only WithUseNumber applies, to keep numbers as json.Number.
+func New[E JSONEqual](t T, data any, optFuncs ...optsFunc) E {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	result, err := NewE[E](data, optFuncs...)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return result
+}
+
+// NewE creates a new instance of type E from the provided data like New,
+// but returns an error instead of failing a test.
+func NewE[E JSONEqual](data any, optFuncs ...optsFunc) (E, error) {
+	var (
+		result  E
+		numbers = newEqualOptions(optFuncs).numbers()
+	)
 
 	switch d := data.(type) {
 	case string:
-		if err := json.Unmarshal([]byte(d), &result); err != nil {
-			t.Fatalf(fmt.Sprintf("%v %s: %v", ErrJSONParse, d, err))
+		if err := unmarshal([]byte(d), &result, numbers); err != nil {
+			return result, fmt.Errorf("%w %s: %v", ErrJSONParse, d, err)
 		}
 	case []byte:
-		if err := json.Unmarshal(d, &result); err != nil {
-			t.Fatalf(fmt.Sprintf("%v %s: %v", ErrJSONParse, string(d), err))
+		if err := unmarshal(d, &result, numbers); err != nil {
+			return result, fmt.Errorf("%w %s: %v", ErrJSONParse, string(d), err)
 		}
 	case E:
-		// If the data is already of type T, we can normalize it and return it directly
-		var err error
-		result, err = normalize(d)
+		// If the data is already of type T, we can normalize it and return a copy of it directly
+		normalized, err := normalizeNumbers(d, numbers)
 		if err != nil {
-			t.Fatalf(fmt.Sprintf("%v %T: %v", ErrNormalize, d, err))
+			return result, fmt.Errorf("%w %T: %v", ErrNormalize, d, err)
 		}
+		result = clone(normalized).(E)
 	default:
-		t.Fatalf(fmt.Sprintf("%T %s", data, ErrUnsupportedType))
+		return result, fmt.Errorf("%T %w", data, ErrUnsupportedType)
 	}
 
-	return result
+	return result, nil
+}
+
+// unmarshal unmarshals data into result like json.Unmarshal, with numbers represented as given by the number mode.
+func unmarshal[E JSONEqual](data []byte, result *E, numbers numberMode) error {
+	if numbers == exactNumbers {
+		switch r := any(result).(type) {
+		case *Obj:
+			return r.unmarshal(data, numbers)
+		case *Arr:
+			return r.unmarshal(data, numbers)
+		case *Val:
+			return r.unmarshal(data, numbers)
+		}
+	}
+	return json.Unmarshal(data, result)
 }
 
 // NewFromFile creates a new instance of type E from a JSON file path.
 // It fails the test via t.Fatalf if the file can't be read or JSON can't be parsed.
-func NewFromFile[E JSONEqual](t T, path string) E {
+// Of the options, only WithUseNumber applies, to keep numbers as json.Number.
+func NewFromFile[E JSONEqual](t T, path string, optFuncs ...optsFunc) E {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	result, err := NewFromFileE[E](path, optFuncs...)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return result
+}
+
+// NewFromFileE creates a new instance of type E from a JSON file path like NewFromFile,
+// but returns an error instead of failing a test.
+func NewFromFileE[E JSONEqual](path string, optFuncs ...optsFunc) (E, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("%v %s: %v", ErrJSONRead, path, err))
+		var empty E
+		return empty, fmt.Errorf("%w %s: %v", ErrJSONRead, path, err)
+	}
+	return NewE[E](data, optFuncs...)
+}
+
+// rootKind is the type of the root of a compared JSON document.
+type rootKind int
+
+const (
+	objectRoot rootKind = iota
+	arrayRoot
+	scalarRoot
+)
+
+func kindOf(v any) rootKind {
+	switch v.(type) {
+	case Obj:
+		return objectRoot
+	case Arr:
+		return arrayRoot
 	}
-	return New[E](t, data)
+	return scalarRoot
 }
 
-func normalizeComparable(t T, data any) (any, bool) {
+func normalizeComparable(data any, opts equalOptions) (any, rootKind, error) {
 	switch d := data.(type) {
 	case Obj:
-		obj, err := normalize(d)
+		obj, err := normalizeNumbers(d, opts.numbers())
 		if err != nil {
-			t.Fatalf(fmt.Sprintf("%v %T: %v", ErrNormalize, d, err))
+			return nil, objectRoot, fmt.Errorf("%w %T: %v", ErrNormalize, d, err)
 		}
-		return obj, true
+		return obj, objectRoot, nil
 	case Arr:
-		arr, err := normalize(d)
+		arr, err := normalizeNumbers(d, opts.numbers())
+		if err != nil {
+			return nil, arrayRoot, fmt.Errorf("%w %T: %v", ErrNormalize, d, err)
+		}
+		return arr, arrayRoot, nil
+	case Val:
+		val, err := normalizeNumbers(d, opts.numbers())
 		if err != nil {
-			t.Fatalf(fmt.Sprintf("%v %T: %v", ErrNormalize, d, err))
+			return nil, scalarRoot, fmt.Errorf("%w %T: %v", ErrNormalize, d, err)
 		}
-		return arr, false
+		return val.Value, kindOf(val.Value), nil
 	case string:
-		return normalizeComparableJSONText(t, []byte(d), d)
+		// a placeholder isn't JSON text, but stands for a scalar root, e.g. Equal(t, "$UUID", body)
+		if _, found := opts.findMatcher(d); found {
+			return d, scalarRoot, nil
+		}
+		return normalizeComparableJSONText([]byte(d), d, opts)
 	case []byte:
-		return normalizeComparableJSONText(t, d, d)
+		return normalizeComparableJSONText(d, d, opts)
 	default:
 		marshaled, err := json.Marshal(d)
 		if err != nil {
-			t.Fatalf(fmt.Sprintf("%T %s", data, ErrUnsupportedType))
+			return nil, scalarRoot, fmt.Errorf("%T %w", data, ErrUnsupportedType)
 		}
-		return normalizeComparableJSONText(t, marshaled, d)
+		return normalizeComparableJSONText(marshaled, d, opts)
 	}
 }
 
-func normalizeComparableJSONText(t T, data []byte, original any) (any, bool) {
+func normalizeComparableJSONText(data []byte, original any, opts equalOptions) (any, rootKind, error) {
 	trimmed := bytes.TrimSpace(data)
 	if len(trimmed) == 0 {
-		t.Fatalf(fmt.Sprintf("%v %s: empty json", ErrJSONParse, string(data)))
+		return nil, scalarRoot, fmt.Errorf("%w %s: empty json", ErrJSONParse, string(data))
 	}
 
 	switch trimmed[0] {
 	case '{':
-		return New[Obj](t, trimmed), true
+		var obj Obj
+		if err := unmarshal(trimmed, &obj, opts.numbers()); err != nil {
+			return obj, objectRoot, fmt.Errorf("%w %s: %v", ErrJSONParse, string(trimmed), err)
+		}
+		return obj, objectRoot, nil
 	case '[':
-		return New[Arr](t, trimmed), false
+		var arr Arr
+		if err := unmarshal(trimmed, &arr, opts.numbers()); err != nil {
+			return arr, arrayRoot, fmt.Errorf("%w %s: %v", ErrJSONParse, string(trimmed), err)
+		}
+		return arr, arrayRoot, nil
 	default:
-		t.Fatalf(fmt.Sprintf("%T %s", original, ErrUnsupportedType))
-	}
-
-	return nil, false
-}
-
-func normalize[T JSONEqual](data T) (T, error) {
-	// Marshal and unmarshal to normalize the JSON structure
-	marshaled, err := json.Marshal(data)
-	if err != nil {
-		return data, fmt.Errorf("error marshalling JSON object: %w", err)
-	}
-
-	var normalized T
-	if err := json.Unmarshal(marshaled, &normalized); err != nil {
-		return data, fmt.Errorf("error unmarshalling JSON object: %w", err)
+		var val Val
+		if err := unmarshal(trimmed, &val, opts.numbers()); err != nil {
+			return nil, scalarRoot, fmt.Errorf("%w %s: %v", ErrJSONParse, string(trimmed), err)
+		}
+		return val.Value, scalarRoot, nil
 	}
-
-	return normalized, nil
 }
 
-func compareValues(path string, expected, actual any, opts equalOptions) (bool, difference) {
+func compareValues(path *location, expected, actual any, opts equalOptions) (bool, difference) {
 	var (
 		diff = difference{
-			path: path,
+			path:     path,
+			expected: expected,
+			actual:   actual,
 		}
 		equal = true
 	)
@@ -155,31 +302,49 @@ func compareValues(path string, expected, actual any, opts equalOptions) (bool,
 	case nil:
 		if actual != nil {
 			diff.diff = unequalMessage(expectedTyped, actual)
+			diff.kind = DiffTypeMismatch
 			equal = false
 		}
-	case bool, float64:
+	case bool:
 		if err := compareTyped(expectedTyped, actual); err != nil {
 			diff.diff = err.Error()
+			diff.kind = mismatchKind(expectedTyped, actual)
+			equal = false
+		}
+	case float64, json.Number:
+		if err := compareNumbers(path, expectedTyped, actual, opts); err != nil {
+			diff.diff = err.Error()
+			diff.kind = mismatchKind(expectedTyped, actual)
 			equal = false
 		}
 	case string:
 		// matcher placeholders have to be strings, so we only need to search them here
-		matcher, found := opts.matchers.FindByPlaceholder(expectedTyped)
+		matcher, found := opts.findMatcher(expectedTyped)
 		if found {
-			if !matcher.MatcherFunc(actual) {
+			err := matcher.Match(actual)
+			if err == nil {
+				err = opts.captures.record(path, expectedTyped, actual, matcher.captures)
+			}
+			if err != nil {
 				diff.diff = fmt.Sprintf("expected value for placeholder %q does not match actual value %v", expectedTyped, actual)
+				if !errors.Is(err, ErrNoMatch) {
+					diff.diff += ": " + err.Error()
+				}
+				diff.kind = DiffMatcherFailed
 				equal = false
 			}
 			break
 		}
 		if err := compareTyped(expectedTyped, actual); err != nil {
 			diff.diff = err.Error()
+			diff.kind = mismatchKind(expectedTyped, actual)
 			equal = false
 		}
 	case Arr:
 		actualTyped, ok := actual.(Arr)
 		if !ok {
 			diff.diff = fmt.Sprintf("expected array - got %T (%v)", actual, actual)
+			diff.kind = DiffTypeMismatch
 			equal = false
 			break
 		}
@@ -192,6 +357,7 @@ func compareValues(path string, expected, actual any, opts equalOptions) (bool,
 		actualTyped, ok := actual.(Obj)
 		if !ok {
 			diff.diff = fmt.Sprintf("expected object - got %T (%v)", actual, actual)
+			diff.kind = DiffTypeMismatch
 			equal = false
 			break
 		}
@@ -201,8 +367,8 @@ func compareValues(path string, expected, actual any, opts equalOptions) (bool,
 			equal = false
 		}
 	default:
-		diff.path = path
 		diff.diff = fmt.Sprintf("unsupported type comparison for expected value %q", expectedTyped)
+		diff.kind = DiffTypeMismatch
 		equal = false
 	}
 
@@ -218,6 +384,32 @@ func compareTyped[T any](expected T, actual any) error {
 	return nil
 }
 
+// compareNumbers compares numbers of the same type, float64 or json.Number, within the tolerance for the location
+// if there is one, and otherwise exactly.
+func compareNumbers(path *location, expected, actual any, opts equalOptions) error {
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		return errors.New(unequalMessage(expected, actual))
+	}
+	if tolerance, found := opts.tolerance(path, floatValue(expected)); found {
+		if delta, exceeds := exceedsTolerance(expected, actual, tolerance); exceeds {
+			return fmt.Errorf("%s, delta %v exceeds tolerance %v", unequalMessage(expected, actual), delta, tolerance)
+		}
+		return nil
+	}
+	if !numbersEqual(expected, actual) {
+		return errors.New(unequalMessage(expected, actual))
+	}
+	return nil
+}
+
+// mismatchKind tells apart values of different JSON types from different values of the same type.
+func mismatchKind(expected, actual any) DiffKind {
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		return DiffTypeMismatch
+	}
+	return DiffValueMismatch
+}
+
 func unequalMessage(expected, actual any) string {
 	msg := `expected ` + formatterFor(expected) + ` - actual ` + formatterFor(actual)
 	return fmt.Sprintf(msg, expected, actual)
@@ -67,7 +67,7 @@ func TestEqual_UnequalKinds_ExpectedArrActualObj(t *testing.T) {
 }
 
 func TestEqual_UnsupportedType(t *testing.T) {
-	assertFatalf(t, "int unsupported type for JSON data, use either string or []byte", func(mt jman.T) {
-		jman.Equal(mt, 123, jman.Obj{"foo": "bar"})
+	assertFatalf(t, "chan int unsupported type for JSON data, use either string or []byte", func(mt jman.T) {
+		jman.Equal(mt, make(chan int), jman.Obj{"foo": "bar"})
 	})
 }
@@ -0,0 +1,260 @@
+package jman
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// expr is a logical expression used by filter selectors, e.g. [?(@.status=="active")].
+type expr interface {
+	test(current, root any) bool
+}
+
+type orExpr struct{ left, right expr }
+
+func (e orExpr) test(current, root any) bool {
+	return e.left.test(current, root) || e.right.test(current, root)
+}
+
+type andExpr struct{ left, right expr }
+
+func (e andExpr) test(current, root any) bool {
+	return e.left.test(current, root) && e.right.test(current, root)
+}
+
+type notExpr struct{ inner expr }
+
+func (e notExpr) test(current, root any) bool {
+	return !e.inner.test(current, root)
+}
+
+// existsExpr is true when the path selects at least one value, e.g. [?(@.email)].
+type existsExpr struct{ path operand }
+
+func (e existsExpr) test(current, root any) bool {
+	return len(e.path.nodes(current, root)) > 0
+}
+
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e compareExpr) test(current, root any) bool {
+	left, leftOK := e.left.value(current, root)
+	right, rightOK := e.right.value(current, root)
+	left, right = filterNumber(left), filterNumber(right)
+
+	switch e.op {
+	case "==":
+		return equalOperands(left, leftOK, right, rightOK)
+	case "!=":
+		return !equalOperands(left, leftOK, right, rightOK)
+	case "<":
+		return lessOperands(left, leftOK, right, rightOK)
+	case ">":
+		return lessOperands(right, rightOK, left, leftOK)
+	case "<=":
+		return lessOperands(left, leftOK, right, rightOK) || equalOperands(left, leftOK, right, rightOK)
+	case ">=":
+		return lessOperands(right, rightOK, left, leftOK) || equalOperands(left, leftOK, right, rightOK)
+	}
+	return false
+}
+
+// filterNumber converts a json.Number to a float64, so that it compares with number literals.
+func filterNumber(v any) any {
+	if n, ok := v.(json.Number); ok {
+		return floatValue(n)
+	}
+	return v
+}
+
+func equalOperands(left any, leftOK bool, right any, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return leftOK == rightOK
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func lessOperands(left any, leftOK bool, right any, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return false
+	}
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		return ok && l < r
+	case string:
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
+
+// operand is either a literal value or a relative (@) or absolute ($) path.
+type operand struct {
+	literal  any
+	isPath   bool
+	relative bool
+	path     jsonPath
+}
+
+func (o operand) nodes(current, root any) []node {
+	start := root
+	if o.relative {
+		start = current
+	}
+	return o.path.nodes(node{value: start}, root)
+}
+
+// value returns the single value of the operand, false if a path operand selects nothing or several values.
+func (o operand) value(current, root any) (any, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	nodes := o.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].value, true
+}
+
+func (p *pathParser) orExpr() (expr, error) {
+	left, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.hasPrefix("||") {
+			return left, nil
+		}
+		p.pos += 2
+		right, err := p.andExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+}
+
+func (p *pathParser) andExpr() (expr, error) {
+	left, err := p.unaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.hasPrefix("&&") {
+			return left, nil
+		}
+		p.pos += 2
+		right, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+}
+
+func (p *pathParser) unaryExpr() (expr, error) {
+	p.skipSpaces()
+	switch {
+	case p.peek() == '!' && !p.hasPrefix("!="):
+		p.pos++
+		inner, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner: inner}, nil
+	case p.peek() == '(':
+		p.pos++
+		inner, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("expected ')' at position %d", p.pos)
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.hasPrefix(op) {
+			continue
+		}
+		p.pos += len(op)
+		p.skipSpaces()
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{op: op, left: left, right: right}, nil
+	}
+
+	if !left.isPath {
+		return nil, fmt.Errorf("literal must be compared at position %d", p.pos)
+	}
+	return existsExpr{path: left}, nil
+}
+
+func (p *pathParser) operand() (operand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == base[0]:
+		start := p.pos
+		p.pos++
+		segments, err := p.segments()
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{
+			isPath:   true,
+			relative: c == '@',
+			path:     jsonPath{raw: p.src[start:p.pos], segments: segments},
+		}, nil
+	case c == '\'' || c == '"':
+		s, err := p.quoted()
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{literal: s}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && isNumberChar(p.src[p.pos]) {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("invalid number %q at position %d", p.src[start:p.pos], start)
+		}
+		return operand{literal: n}, nil
+	}
+
+	for word, literal := range map[string]any{"true": true, "false": false, "null": nil} {
+		if p.hasPrefix(word) {
+			p.pos += len(word)
+			return operand{literal: literal}, nil
+		}
+	}
+
+	if p.pos >= len(p.src) {
+		return operand{}, errors.New("unexpected end of filter expression")
+	}
+	return operand{}, fmt.Errorf("unexpected character %q at position %d", p.peek(), p.pos)
+}
+
+func isNumberChar(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
@@ -2,50 +2,250 @@ package jman
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
-	"strings"
 )
 
 var numberRegex = regexp.MustCompile(`^\d+$`)
 
-func getValue[R JSONEqual](path string, data R) (any, error) {
-	paths, err := getPathParts(path)
+// node is a value selected by a path, along with its location and a way to replace it in its parent.
+// parent and set are nil for the root value.
+type node struct {
+	value  any
+	path   *location
+	parent *node
+	set    func(v any)
+}
+
+func getValue(path string, data any) (any, error) {
+	p, err := parsePath(path)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(paths) == 0 {
-		return nil, fmt.Errorf("no path segments provided")
+	if p.singular() {
+		return lookupSingular(p, data)
+	}
+
+	nodes := p.nodes(node{value: data}, data)
+	switch len(nodes) {
+	case 0:
+		return nil, fmt.Errorf("no value found at path '%s'", path)
+	case 1:
+		return nodes[0].value, nil
+	default:
+		return nil, fmt.Errorf("path '%s' matched %d values, use GetAll to retrieve all of them", path, len(nodes))
+	}
+}
+
+func getAll(path string, data any) (Arr, error) {
+	p, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := p.nodes(node{value: data}, data)
+	values := make(Arr, len(nodes))
+	for i, n := range nodes {
+		values[i] = n.value
+	}
+	return values, nil
+}
+
+// lookupSingular walks a singular path one segment at a time so that failures can be reported precisely.
+func lookupSingular(p jsonPath, data any) (any, error) {
+	current := data
+	for _, seg := range p.segments {
+		sel := seg.selectors[0]
+		switch typed := current.(type) {
+		case Obj:
+			if sel.kind == indexSelector {
+				return nil, fmt.Errorf("expected an array at path '%s', got %T", p, current)
+			}
+			val, ok := typed[sel.name]
+			if !ok {
+				return nil, fmt.Errorf("key '%s' not found in object", sel.name)
+			}
+			current = val
+		case Arr:
+			index, ok := sel.arrayIndex(len(typed))
+			if !ok {
+				return nil, fmt.Errorf("expected an object at path '%s', got %T", p, current)
+			}
+			if index < 0 || index >= len(typed) {
+				return nil, fmt.Errorf("index %d out of bounds for array of length %d", index, len(typed))
+			}
+			current = typed[index]
+		default:
+			return nil, fmt.Errorf("expected an object or array at path '%s', got %T", p, current)
+		}
+	}
+
+	return current, nil
+}
+
+// nodes evaluates the path against start, where root is the document used by absolute paths in filters.
+func (p jsonPath) nodes(start node, root any) []node {
+	current := []node{start}
+	for _, seg := range p.segments {
+		var next []node
+		for _, n := range current {
+			next = seg.apply(n, root, next)
+		}
+		current = next
 	}
-	current := any(data)
-	for _, path := range paths {
-		if path == base {
-			continue
+	return current
+}
+
+func (seg segment) apply(n node, root any, out []node) []node {
+	candidates := []node{n}
+	if seg.descendant {
+		candidates = descendants(n, nil)
+	}
+	for _, c := range candidates {
+		for _, sel := range seg.selectors {
+			out = sel.apply(c, root, out)
 		}
-		if isIndex(path) {
-			index, _ := strconv.Atoi(path) // converting after checking regex, no need for error handling here
-			if arr, ok := current.(Arr); ok {
-				if index < 0 || index >= len(arr) {
-					return nil, fmt.Errorf("index %d out of bounds for array of length %d", index, len(arr))
-				}
-				current = arr[index]
-			} else {
-				return nil, fmt.Errorf("expected an array at path '%s', got %T", strings.Join(paths, "."), current)
+	}
+	return out
+}
+
+func (sel selector) apply(n node, root any, out []node) []node {
+	switch sel.kind {
+	case memberSelector, nameSelector:
+		if obj, ok := n.value.(Obj); ok {
+			if _, exists := obj[sel.name]; exists {
+				return append(out, objNode(&n, obj, sel.name))
 			}
-		} else {
-			if converted, ok := current.(Obj); ok {
-				if val, ok := converted[path]; ok {
-					current = val
-				} else {
-					return nil, fmt.Errorf("key '%s' not found in object", path)
-				}
+			return out
+		}
+		fallthrough
+	case indexSelector:
+		arr, ok := n.value.(Arr)
+		if !ok {
+			return out
+		}
+		if index, ok := sel.arrayIndex(len(arr)); ok && index >= 0 && index < len(arr) {
+			return append(out, arrNode(&n, arr, index))
+		}
+	case wildcardSelector:
+		return append(out, children(n)...)
+	case sliceSelector:
+		if arr, ok := n.value.(Arr); ok {
+			for _, index := range sel.slice.indices(len(arr)) {
+				out = append(out, arrNode(&n, arr, index))
 			}
+		}
+	case filterSelector:
+		for _, child := range children(n) {
+			if sel.filter.test(child.value, root) {
+				out = append(out, child)
+			}
+		}
+	}
+	return out
+}
 
+// arrayIndex converts the selector into an index for an array of the given length.
+// Negative indices count from the end of the array.
+func (sel selector) arrayIndex(length int) (int, bool) {
+	switch sel.kind {
+	case indexSelector:
+		if sel.index < 0 {
+			return length + sel.index, true
 		}
+		return sel.index, true
+	case memberSelector:
+		if !isIndex(sel.name) {
+			return 0, false
+		}
+		index, err := strconv.Atoi(sel.name)
+		return index, err == nil
 	}
+	return 0, false
+}
 
-	return current, nil
+func (s slice) indices(length int) []int {
+	if s.step == 0 {
+		return nil
+	}
+
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+	bound := func(i *int, fallback, lower, upper int) int {
+		if i == nil {
+			return fallback
+		}
+		return min(max(normalize(*i), lower), upper)
+	}
+
+	var indices []int
+	if s.step > 0 {
+		start, end := bound(s.start, 0, 0, length), bound(s.end, length, 0, length)
+		for i := start; i < end; i += s.step {
+			indices = append(indices, i)
+		}
+		return indices
+	}
+
+	start, end := bound(s.start, length-1, -1, length-1), bound(s.end, -1, -1, length-1)
+	for i := start; i > end; i += s.step {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// children returns the direct children of an object or array, objects ordered by key.
+func children(n node) []node {
+	switch typed := n.value.(type) {
+	case Obj:
+		keys := slices.Sorted(maps.Keys(typed))
+		out := make([]node, len(keys))
+		for i, k := range keys {
+			out[i] = objNode(&n, typed, k)
+		}
+		return out
+	case Arr:
+		out := make([]node, len(typed))
+		for i := range typed {
+			out[i] = arrNode(&n, typed, i)
+		}
+		return out
+	}
+	return nil
+}
+
+// descendants returns n followed by all of its descendants in document order.
+func descendants(n node, out []node) []node {
+	out = append(out, n)
+	for _, child := range children(n) {
+		out = descendants(child, out)
+	}
+	return out
+}
+
+func objNode(parent *node, obj Obj, key string) node {
+	return node{
+		value:  obj[key],
+		path:   parent.path.child(key),
+		parent: parent,
+		set:    func(v any) { obj[key] = v },
+	}
+}
+
+func arrNode(parent *node, arr Arr, index int) node {
+	return node{
+		value:  arr[index],
+		path:   parent.path.item(index),
+		parent: parent,
+		set:    func(v any) { arr[index] = v },
+	}
 }
 
 func isIndex(segment string) bool {
@@ -0,0 +1,146 @@
+package jman
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that, when set to a true value like 1, makes MatchGolden
+// rewrite golden files instead of comparing against them, like the -jman.update flag, which is only
+// registered in test binaries.
+const UpdateEnv = "JMAN_UPDATE"
+
+var update = updateFlag()
+
+// updateFlag registers the -jman.update flag in test binaries only, so that programs using jman
+// outside of go test don't get a flag they didn't define.
+func updateFlag() *bool {
+	if !testing.Testing() {
+		return new(bool)
+	}
+	return flag.Bool("jman.update", false, "rewrite the golden files compared by jman.MatchGolden with the actual values")
+}
+
+// MatchGolden compares actual against the JSON in the golden file at path like Equal.
+// Placeholders in the golden file are resolved with the matchers given in the options.
+//
+// When the tests are run with -jman.update or the JMAN_UPDATE environment variable, it writes the
+// pretty-printed actual value to the file instead of failing. Placeholders already in the file are
+// kept wherever the actual value still satisfies their matcher, so dynamic values don't end up in the file.
+func MatchGolden(t T, path string, actual any, optFuncs ...optsFunc) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if updatingGolden() {
+		if err := writeGolden(path, actual, optFuncs); err != nil {
+			t.Fatalf(err.Error())
+		}
+		return
+	}
+
+	golden, err := readGolden(path, newEqualOptions(optFuncs))
+	if err != nil {
+		t.Fatalf(err.Error())
+		return
+	}
+	report, err := Compare(golden, actual, optFuncs...)
+	if err != nil {
+		t.Fatalf(err.Error())
+		return
+	}
+	if !report.Equal() {
+		t.Fatalf(fmt.Sprintf("golden file %s does not match, run with -jman.update or %s=1 to rewrite it\n%s", path, UpdateEnv, report))
+	}
+}
+
+func updatingGolden() bool {
+	if *update {
+		return true
+	}
+	env, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return env
+}
+
+func readGolden(path string, opts equalOptions) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrJSONRead, path, err)
+	}
+	golden, kind, err := normalizeComparableJSONText(data, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	// a scalar root, e.g. the string "ok", is compared as a value rather than as JSON text, like in Equal
+	if kind == scalarRoot {
+		return Val{Value: golden}, nil
+	}
+	return golden, nil
+}
+
+func writeGolden(path string, actual any, optFuncs []optsFunc) error {
+	opts := newEqualOptions(optFuncs)
+	actualVal, _, err := normalizeComparable(actual, opts)
+	if err != nil {
+		return err
+	}
+	// an unreadable golden file is simply replaced
+	if golden, err := readGolden(path, opts); err == nil {
+		actualVal = keepPlaceholders(golden, actualVal, opts)
+	}
+
+	data, err := json.MarshalIndent(actualVal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling golden file %s: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error writing golden file %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing golden file %s: %v", path, err)
+	}
+	return nil
+}
+
+// keepPlaceholders returns actual with the placeholders of golden put back wherever the actual value satisfies their matcher.
+func keepPlaceholders(golden, actual any, opts equalOptions) any {
+	switch goldenTyped := golden.(type) {
+	case Val:
+		return keepPlaceholders(goldenTyped.Value, actual, opts)
+	case string:
+		if matcher, found := opts.findMatcher(goldenTyped); found && matcher.Match(actual) == nil {
+			return goldenTyped
+		}
+	case Obj:
+		actualTyped, ok := actual.(Obj)
+		if !ok {
+			break
+		}
+		kept := make(Obj, len(actualTyped))
+		for k, v := range actualTyped {
+			kept[k] = v
+			if goldenValue, exists := goldenTyped[k]; exists {
+				kept[k] = keepPlaceholders(goldenValue, v, opts)
+			}
+		}
+		return kept
+	case Arr:
+		actualTyped, ok := actual.(Arr)
+		if !ok {
+			break
+		}
+		kept := make(Arr, len(actualTyped))
+		for i, v := range actualTyped {
+			kept[i] = v
+			if i < len(goldenTyped) {
+				kept[i] = keepPlaceholders(goldenTyped[i], v, opts)
+			}
+		}
+		return kept
+	}
+	return actual
+}
@@ -0,0 +1,116 @@
+package jman_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+const goldenUUID = "9b74c989-7cdf-41fa-9a49-5290f31e59d3"
+
+func writeGoldenFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "golden.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestMatchGolden(t *testing.T) {
+	path := writeGoldenFile(t, `{"id":"$UUID","name":"alice","tags":["a","b"]}`)
+
+	jman.MatchGolden(t, path, jman.Obj{"id": goldenUUID, "name": "alice", "tags": jman.Arr{"a", "b"}},
+		jman.WithMatchers(jman.IsUUID("$UUID")))
+}
+
+func TestMatchGolden_ScalarRoot(t *testing.T) {
+	jman.MatchGolden(t, writeGoldenFile(t, `"ok"`), `"ok"`)
+	jman.MatchGolden(t, writeGoldenFile(t, "null\n"), []byte("null"))
+	jman.MatchGolden(t, writeGoldenFile(t, `"$UUID"`), jman.Val{Value: goldenUUID}, jman.WithMatchers(jman.IsUUID("$UUID")))
+
+	path := writeGoldenFile(t, `"ok"`)
+	expectedMsg := fmt.Sprintf(`golden file %s does not match, run with -jman.update or JMAN_UPDATE=1 to rewrite it
+expected not equal to actual:
+expected "ok"
+actual null
+
+$ expected "ok" - actual <nil>
+`, path)
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.MatchGolden(mt, path, "null")
+	})
+}
+
+func TestMatchGolden_UpdateKeepsScalarPlaceholder(t *testing.T) {
+	t.Setenv(jman.UpdateEnv, "1")
+	path := writeGoldenFile(t, `"$UUID"`)
+
+	jman.MatchGolden(t, path, jman.Val{Value: goldenUUID}, jman.WithMatchers(jman.IsUUID("$UUID")))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "\"$UUID\"\n", string(data))
+}
+
+func TestMatchGolden_NotEqual(t *testing.T) {
+	path := writeGoldenFile(t, `{"name":"alice"}`)
+
+	expectedMsg := fmt.Sprintf(`golden file %s does not match, run with -jman.update or JMAN_UPDATE=1 to rewrite it
+expected not equal to actual:
+expected {"name":"alice"}
+actual {"name":"bob"}
+
+$.name expected "alice" - actual "bob"
+`, path)
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.MatchGolden(mt, path, `{"name":"bob"}`)
+	})
+}
+
+func TestMatchGolden_FileNotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	assertFatalf(t, fmt.Sprintf("error reading JSON file %s:", path), func(mt jman.T) {
+		jman.MatchGolden(mt, path, jman.Obj{})
+	})
+}
+
+func TestMatchGolden_UpdateCreatesFile(t *testing.T) {
+	t.Setenv(jman.UpdateEnv, "1")
+	path := filepath.Join(t.TempDir(), "nested", "golden.json")
+
+	jman.MatchGolden(t, path, `[{"b":1,"a":true}]`)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  {\n    \"a\": true,\n    \"b\": 1\n  }\n]\n", string(data))
+}
+
+func TestMatchGolden_UpdateKeepsPlaceholders(t *testing.T) {
+	t.Setenv(jman.UpdateEnv, "true")
+	path := writeGoldenFile(t, `{"id":"$UUID","owner":{"id":"$UUID"},"items":["$UUID","$UUID"],"name":"alice"}`)
+
+	actual := jman.Obj{
+		"id":    goldenUUID,
+		"owner": jman.Obj{"id": "not-a-uuid"},
+		"items": jman.Arr{goldenUUID, goldenUUID, goldenUUID},
+		"name":  "bob",
+	}
+	jman.MatchGolden(t, path, actual, jman.WithMatchers(jman.IsUUID("$UUID")))
+
+	golden := jman.NewFromFile[jman.Obj](t, path)
+	assert.Equal(t, jman.Obj{
+		"id":    "$UUID",
+		"owner": jman.Obj{"id": "not-a-uuid"},
+		"items": jman.Arr{"$UUID", "$UUID", goldenUUID},
+		"name":  "bob",
+	}, golden)
+}
+
+func TestMatchGolden_UpdateFlagInTests(t *testing.T) {
+	assert.NotNil(t, flag.Lookup("jman.update"), "the flag is registered in test binaries")
+}
@@ -0,0 +1,84 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_IgnorePaths(t *testing.T) {
+	expected := jman.Obj{
+		"meta":  jman.Obj{"version": 2},
+		"items": jman.Arr{jman.Obj{"id": 1, "updatedAt": "2024-01-01"}, jman.Obj{"id": 2}},
+	}
+	actual := jman.Obj{
+		"meta":  jman.Obj{"version": 2, "requestId": "abc"},
+		"items": jman.Arr{jman.Obj{"id": 1, "updatedAt": "2025-06-30"}, jman.Obj{"id": 2, "updatedAt": "2025-07-01"}},
+	}
+
+	jman.Equal(t, expected, actual, jman.WithIgnorePaths("$.meta.requestId", "$.items.*.updatedAt"))
+}
+
+func TestEqual_IgnorePaths_Unequal(t *testing.T) {
+	expected := jman.Obj{"meta": jman.Obj{"requestId": "abc"}, "id": 1}
+	actual := jman.Obj{"meta": jman.Obj{}, "id": 2, "requestId": "def"}
+
+	expectedMsg := `expected not equal to actual:
+expected {"id":1,"meta":{"requestId":"abc"}}
+actual {"id":2,"meta":{},"requestId":"def"}
+
+$.requestId unexpected key
+$.id expected 1 - actual 2
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, expected, actual, jman.WithIgnorePaths("/meta/requestId"))
+	})
+}
+
+func TestEqual_IgnoreKeys(t *testing.T) {
+	expected := jman.Obj{
+		"etag":  "v1",
+		"owner": jman.Obj{"name": "alice"},
+		"items": jman.Arr{jman.Obj{"sku": "a", "createdAt": "yesterday"}},
+	}
+	actual := jman.Obj{
+		"owner": jman.Obj{"name": "alice", "createdAt": "2025-06-30"},
+		"items": jman.Arr{jman.Obj{"sku": "a", "createdAt": "2025-07-01", "etag": "v2"}},
+	}
+
+	jman.Equal(t, expected, actual, jman.WithIgnoreKeys("createdAt", "etag"))
+}
+
+func TestEqual_IgnoreOptions_Invalid(t *testing.T) {
+	assertFatalf(t, "invalid options: path must start with $ or be a JSON pointer starting with /", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnorePaths("meta.requestId"))
+	})
+	assertFatalf(t, "invalid options: ignored key cannot be empty", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnoreKeys(""))
+	})
+}
+
+func TestEqual_PathOptions_ValueDependentPaths(t *testing.T) {
+	assertFatalf(t, "invalid options: path '$.a[-1].x' can't be used in options: negative index -1 depends on the array length", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnorePaths("$.a[-1].x"))
+	})
+	assertFatalf(t, "invalid options: path '$.a[-1:].x' can't be used in options: slices with negative bounds or steps depend on the array length", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnorePaths("$.a[-1:].x"))
+	})
+	assertFatalf(t, "invalid options: path '$.a[::-1]' can't be used in options: slices with negative bounds or steps depend on the array length", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnoreArrayOrder("$.a[::-1]"))
+	})
+	assertFatalf(t, "invalid options: path '$.a[?(@.x==2)].x' can't be used in options: filters depend on the values compared", func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{}, jman.Obj{}, jman.WithIgnorePaths("$.a[?(@.x==2)].x"))
+	})
+
+	_, err := jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithAllowExtraKeysAt("$.a[-1]"))
+	assert.ErrorContains(t, err, "negative index -1 depends on the array length")
+	_, err = jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithArrayKey("$.a[-1]", "id"))
+	assert.ErrorContains(t, err, "negative index -1 depends on the array length")
+	_, err = jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithFloatToleranceAt("$.a[-1]", 0.1))
+	assert.ErrorContains(t, err, "negative index -1 depends on the array length")
+
+	jman.Equal(t, `{"a": [{"x": 1}, {"x": 2}, {"x": 3}]}`, `{"a": [{"x": 1}, {"x": 0}, {"x": 0}]}`, jman.WithIgnorePaths("$.a[1:].x"))
+}
@@ -4,31 +4,68 @@ package jman
 
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+// ErrNoMatch is returned by Matcher.Match for a value rejected by a MatcherFunc, which gives no reason.
+var ErrNoMatch = errors.New("value does not match")
+
 // Matchers is a collection of Matcher objects.
 type Matchers []Matcher
 
-// FindByPlaceholder searches for a Matcher by its Placeholder.
+// FindByPlaceholder searches for a Matcher by its Placeholder. If several matchers have the placeholder,
+// it returns a matcher that checks that a value matches all of them, like AllOf, and captures the value
+// for those created with Capture.
+// Matchers without a placeholder are never found, as they are only meant to be combined.
 func (m Matchers) FindByPlaceholder(p string) (Matcher, bool) {
+	if p == "" {
+		return Matcher{}, false
+	}
+	var found Matchers
 	for _, matcher := range m {
 		if matcher.Placeholder == p {
-			return matcher, true
+			found = append(found, matcher)
 		}
 	}
 
-	return Matcher{}, false
+	switch len(found) {
+	case 0:
+		return Matcher{}, false
+	case 1:
+		return found[0], true
+	}
+	combined := AllOf(p, found...)
+	for _, matcher := range found {
+		combined.captures = append(combined.captures, matcher.captures...)
+	}
+	return combined, true
 }
 
 // Matcher represents a matcher that can be used to validate values against certain conditions.
 // It contains a placeholder for identification and a function that defines the matching logic.
 // It will be checked whenever a string value is encountered in the expected value.
 // If a matcher with the same placeholder is found, it will be used to validate the actual value.
+// The MatchFunc is used if it is set, as it can explain why a value doesn't match, otherwise the MatcherFunc.
 type Matcher struct {
 	Placeholder string
 	MatcherFunc
+	MatchFunc
+
+	// captures store the matched value, for a matcher created with Capture.
+	captures []func(v any)
 }
 
 // MatcherFunc is a function type that defines the matching logic.
@@ -36,59 +73,148 @@ type Matcher struct {
 // the value passed into the MatcherFunc can be of any type, including nil and is taken from the actual value in the JSON.
 type MatcherFunc func(v any) bool
 
+// MatchFunc is a function type that defines the matching logic like MatcherFunc, but returns nil if the value matches
+// and otherwise an error with the reason it doesn't, e.g. "not a UUID (version nibble 7 out of range)".
+// The reason is shown in the difference reported for the value.
+type MatchFunc func(v any) error
+
+// Match checks the value against the matcher. It returns nil if the value matches, otherwise the reason
+// it doesn't, or ErrNoMatch if the matcher only has a MatcherFunc.
+func (m Matcher) Match(v any) error {
+	if m.MatchFunc != nil {
+		return m.MatchFunc(v)
+	}
+	if m.MatcherFunc != nil && m.MatcherFunc(v) {
+		return nil
+	}
+	return ErrNoMatch
+}
+
+// newMatcher creates a matcher from a MatchFunc, with a MatcherFunc for callers that use it directly.
+func newMatcher(placeholder string, matchFunc MatchFunc) Matcher {
+	return Matcher{
+		Placeholder: placeholder,
+		MatcherFunc: func(v any) bool { return matchFunc(v) == nil },
+		MatchFunc:   matchFunc,
+	}
+}
+
 // NotEmpty creates a matcher that checks if the value is not empty.
 // a string must not be empty, cannot be nil.  booleans can be either true or false.
 // a number can be any number, including zero.
 // an array must not be empty, cannot be nil.  
 // an object must not be empty, cannot be nil.
 func NotEmpty(placeholder string) Matcher {
-	return Matcher{
-		Placeholder: placeholder,
-		MatcherFunc: func(v any) bool {
-			switch typed := v.(type) {
-			case nil:
-				return false
-			case bool, float64:
-				return true
-			case string:
-				return typed != ""
-			case []any:
-				return len(typed) > 0
-			case map[string]any:
-				return len(typed) > 0
+	return newMatcher(placeholder, func(v any) error {
+		switch typed := v.(type) {
+		case nil:
+			return errors.New("null")
+		case bool, float64, json.Number:
+			return nil
+		case string:
+			if typed == "" {
+				return errors.New("empty string")
+			}
+			return nil
+		case Arr:
+			if len(typed) == 0 {
+				return errors.New("empty array")
+			}
+			return nil
+		case Obj:
+			if len(typed) == 0 {
+				return errors.New("empty object")
 			}
+			return nil
+		}
 
-			return false
-		},
-	}
+		return fmt.Errorf("unsupported type %s", jsonType(v))
+	})
 }
 
-var uuidRegex = regexp.MustCompile(`^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[1-5][a-fA-F0-9]{3}-[89abAB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$`)
-
-// IsUUID creates a matcher that checks if the value is a valid UUID against a uuid regular expression.
+// IsUUID creates a matcher that checks if the value is a string with a valid UUID of version 1 to 5,
+// in the 8-4-4-4-12 hexadecimal format with the RFC 4122 variant.
 func IsUUID(placeholder string) Matcher {
-	return Matcher{
-		Placeholder: placeholder,
-		MatcherFunc: func(v any) bool {
-			if str, ok := v.(string); ok {
-				return uuidRegex.MatchString(str)
+	return stringMatcher(placeholder, "a UUID", checkUUID)
+}
+
+// stringMatcher creates a matcher for strings that check accepts, returning what is wrong with a string otherwise.
+// The reason a value doesn't match names what it should be, e.g. "not a UUID (number instead of string)".
+func stringMatcher(placeholder, what string, check func(s string) string) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("not %s (%s instead of string)", what, jsonType(v))
+		}
+		if problem := check(str); problem != "" {
+			return fmt.Errorf("not %s (%s)", what, problem)
+		}
+		return nil
+	})
+}
+
+// jsonType returns the name of the JSON type of a normalized value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case Obj:
+		return "object"
+	case Arr:
+		return "array"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// checkUUID returns what is wrong with a UUID, or an empty string if it is valid.
+func checkUUID(s string) string {
+	if len(s) != 36 {
+		return fmt.Sprintf("length %d instead of 36", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return fmt.Sprintf("%q instead of '-' at index %d", c, i)
 			}
-			return false
-		},
+		case !isHexDigit(c):
+			return fmt.Sprintf("invalid character %q at index %d", c, i)
+		}
+	}
+	if s[14] < '1' || s[14] > '5' {
+		return fmt.Sprintf("version nibble %c out of range", s[14])
 	}
+	switch s[19] {
+	case '8', '9', 'a', 'b', 'A', 'B':
+	default:
+		return fmt.Sprintf("variant nibble %c out of range", s[19])
+	}
+	return ""
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
 }
 
 // EqualMatcher checks if the value matches the expected value.
 func EqualMatcher[T any](placeholder string, expected T) Matcher {
-	return Matcher{
-		Placeholder: placeholder,
-		MatcherFunc: func(v any) bool {
-			if typed, ok := v.(T); ok {
-				return reflect.DeepEqual(typed, expected)
-			}
-			return false
-		},
-	}
+	return newMatcher(placeholder, func(v any) error {
+		typed, ok := v.(T)
+		if !ok {
+			return fmt.Errorf("expected %T "+formatterFor(expected)+" - got %T", expected, expected, v)
+		}
+		if !reflect.DeepEqual(typed, expected) {
+			return fmt.Errorf("expected "+formatterFor(expected), expected)
+		}
+		return nil
+	})
 }
 
 // Custom creates a matcher with a custom matching function.
@@ -98,3 +224,375 @@ func Custom(placeholder string, matcherFunc MatcherFunc) Matcher {
 		MatcherFunc: matcherFunc,
 	}
 }
+
+// CustomMatch creates a matcher with a custom matching function that returns the reason a value doesn't match,
+// e.g. jman.CustomMatch("$EVEN", func(v any) error { ... return fmt.Errorf("%v is odd", v) }).
+func CustomMatch(placeholder string, matchFunc MatchFunc) Matcher {
+	return Matcher{
+		Placeholder: placeholder,
+		MatchFunc:   matchFunc,
+	}
+}
+
+// IsString creates a matcher that checks if the value is a string.
+// The reason for other values names their type, e.g. "number instead of string".
+func IsString(placeholder string) Matcher {
+	return typeMatcher(placeholder, "string")
+}
+
+// IsNumber creates a matcher that checks if the value is a number.
+// The reason for other values names their type, e.g. "string instead of number".
+func IsNumber(placeholder string) Matcher {
+	return typeMatcher(placeholder, "number")
+}
+
+// IsBool creates a matcher that checks if the value is true or false.
+// The reason for other values names their type, e.g. "string instead of boolean".
+func IsBool(placeholder string) Matcher {
+	return typeMatcher(placeholder, "boolean")
+}
+
+// IsNull creates a matcher that checks if the value is null.
+// The reason for other values names their type, e.g. "string instead of null".
+func IsNull(placeholder string) Matcher {
+	return typeMatcher(placeholder, "null")
+}
+
+// IsObject creates a matcher that checks if the value is an object, empty or not.
+// The reason for other values names their type, e.g. "array instead of object".
+func IsObject(placeholder string) Matcher {
+	return typeMatcher(placeholder, "object")
+}
+
+// IsArray creates a matcher that checks if the value is an array, empty or not.
+// The reason for other values names their type, e.g. "object instead of array".
+func IsArray(placeholder string) Matcher {
+	return typeMatcher(placeholder, "array")
+}
+
+func typeMatcher(placeholder, typ string) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		if got := jsonType(v); got != typ {
+			return fmt.Errorf("%s instead of %s", got, typ)
+		}
+		return nil
+	})
+}
+
+// IsInteger creates a matcher that checks if the value is a number without a fractional part, e.g. 42 or 1e3.
+// The reason is "not an integer (1.5)", or names the type of a value that is not a number.
+func IsInteger(placeholder string) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		if jsonType(v) != "number" {
+			return fmt.Errorf("not an integer (%s instead of number)", jsonType(v))
+		}
+		if _, ok := integerValue(v); !ok {
+			return fmt.Errorf("not an integer (%v)", v)
+		}
+		return nil
+	})
+}
+
+// InRange creates a matcher that checks if the value is a number from min to max, both included.
+// The reason is e.g. "12 not between 1 and 10", or names the type of a value that is not a number.
+func InRange(placeholder string, min, max float64) Matcher {
+	lower, upper := new(big.Rat).SetFloat64(min), new(big.Rat).SetFloat64(max)
+	return newMatcher(placeholder, func(v any) error {
+		n := exactValue(v)
+		if n == nil {
+			return fmt.Errorf("%s instead of number", jsonType(v))
+		}
+		if lower == nil || upper == nil || n.Cmp(lower) < 0 || n.Cmp(upper) > 0 {
+			return fmt.Errorf("%v not between %v and %v", v, min, max)
+		}
+		return nil
+	})
+}
+
+// LenBetween creates a matcher that checks if the value is a string of min to max characters, or an array
+// or object of min to max items, both included. The reason is e.g. "length 12 not between 1 and 10",
+// or e.g. "number has no length" for other values.
+func LenBetween(placeholder string, min, max int) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		n, err := jsonLen(v)
+		if err != nil {
+			return err
+		}
+		if n < min || n > max {
+			return fmt.Errorf("length %d not between %d and %d", n, min, max)
+		}
+		return nil
+	})
+}
+
+// jsonLen returns the number of characters of a string, or the number of items of an array or object.
+func jsonLen(v any) (int, error) {
+	switch typed := v.(type) {
+	case string:
+		return utf8.RuneCountInString(typed), nil
+	case Arr:
+		return len(typed), nil
+	case Obj:
+		return len(typed), nil
+	}
+	return 0, fmt.Errorf("%s has no length", jsonType(v))
+}
+
+// HasPrefix creates a matcher that checks if the value is a string starting with prefix.
+// The reason is e.g. `does not start with "ord_"`, or names the type of a value that is not a string.
+func HasPrefix(placeholder, prefix string) Matcher {
+	return substringMatcher(placeholder, "start with", prefix, strings.HasPrefix)
+}
+
+// HasSuffix creates a matcher that checks if the value is a string ending with suffix.
+// The reason is e.g. `does not end with ".png"`, or names the type of a value that is not a string.
+func HasSuffix(placeholder, suffix string) Matcher {
+	return substringMatcher(placeholder, "end with", suffix, strings.HasSuffix)
+}
+
+// HasSubstring creates a matcher that checks if the value is a string containing substr.
+// The reason is e.g. `does not contain "@"`, or names the type of a value that is not a string.
+func HasSubstring(placeholder, substr string) Matcher {
+	return substringMatcher(placeholder, "contain", substr, strings.Contains)
+}
+
+func substringMatcher(placeholder, relation, substr string, has func(s, substr string) bool) Matcher {
+	return newMatcher(placeholder, func(v any) error {
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s instead of string", jsonType(v))
+		}
+		if !has(str, substr) {
+			return fmt.Errorf("does not %s %q", relation, substr)
+		}
+		return nil
+	})
+}
+
+// MatchesRegex creates a matcher that checks if the value is a string matching the regular expression.
+// The reason is e.g. `does not match regular expression "^ord_"`, or names the type of a value that is not
+// a string. It panics if the regular expression is invalid, like regexp.MustCompile.
+func MatchesRegex(placeholder, pattern string) Matcher {
+	return newMatcher(placeholder, regexMatchFunc(regexp.MustCompile(pattern)))
+}
+
+func regexMatchFunc(re *regexp.Regexp) MatchFunc {
+	return func(v any) error {
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s instead of string", jsonType(v))
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("does not match regular expression %q", re)
+		}
+		return nil
+	}
+}
+
+// IsRFC3339 creates a matcher that checks if the value is an RFC 3339 timestamp, e.g. "2024-05-01T12:30:00Z",
+// with optional fractional seconds. The reason is e.g. "not an RFC 3339 timestamp (month out of range)".
+func IsRFC3339(placeholder string) Matcher {
+	return stringMatcher(placeholder, "an RFC 3339 timestamp", func(s string) string {
+		return checkTime(s, time.RFC3339Nano, "2006-01-02T15:04:05Z07:00")
+	})
+}
+
+// IsDate creates a matcher that checks if the value is a calendar date, e.g. "2024-05-01".
+// The reason is e.g. "not a date (day out of range)".
+func IsDate(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a date", func(s string) string {
+		return checkTime(s, time.DateOnly, time.DateOnly)
+	})
+}
+
+// checkTime returns what is wrong with a time in the layout, described by example.
+func checkTime(s, layout, example string) string {
+	_, err := time.Parse(layout, s)
+	if err == nil {
+		return ""
+	}
+	var parseErr *time.ParseError
+	if errors.As(err, &parseErr) && parseErr.Message != "" {
+		return strings.TrimPrefix(parseErr.Message, ": ")
+	}
+	return "expected the format " + example
+}
+
+// IsEmail creates a matcher that checks if the value is a bare email address as accepted by net/mail,
+// e.g. "alice@example.com" but not "Alice <alice@example.com>". The reason is e.g. "not an email address (no @)".
+func IsEmail(placeholder string) Matcher {
+	return stringMatcher(placeholder, "an email address", func(s string) string {
+		addr, err := mail.ParseAddress(s)
+		switch {
+		case !strings.Contains(s, "@"):
+			return "no @"
+		case err != nil:
+			return strings.TrimPrefix(err.Error(), "mail: ")
+		case addr.Address != s:
+			return "not a bare address"
+		}
+		return ""
+	})
+}
+
+// IsURL creates a matcher that checks if the value is an absolute URL with a scheme and host,
+// e.g. "https://example.com/path". The reason is e.g. "not a URL (no scheme)".
+func IsURL(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a URL", func(s string) string {
+		u, err := url.Parse(s)
+		switch {
+		case err != nil:
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				return urlErr.Err.Error()
+			}
+			return err.Error()
+		case u.Scheme == "":
+			return "no scheme"
+		case u.Host == "":
+			return "no host"
+		}
+		return ""
+	})
+}
+
+// IsIPv4 creates a matcher that checks if the value is an IPv4 address in dotted decimal notation, e.g. "192.0.2.1".
+// The reason is e.g. "not an IPv4 address (IPv6 address)".
+func IsIPv4(placeholder string) Matcher {
+	return stringMatcher(placeholder, "an IPv4 address", func(s string) string {
+		addr, err := netip.ParseAddr(s)
+		switch {
+		case err != nil:
+			return "invalid address"
+		case !addr.Is4():
+			return "IPv6 address"
+		}
+		return ""
+	})
+}
+
+// IsIPv6 creates a matcher that checks if the value is an IPv6 address, e.g. "2001:db8::1".
+// The reason is e.g. "not an IPv6 address (IPv4 address)".
+func IsIPv6(placeholder string) Matcher {
+	return stringMatcher(placeholder, "an IPv6 address", func(s string) string {
+		addr, err := netip.ParseAddr(s)
+		switch {
+		case err != nil:
+			return "invalid address"
+		case !addr.Is6():
+			return "IPv4 address"
+		}
+		return ""
+	})
+}
+
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// IsSemver creates a matcher that checks if the value is a semantic version, e.g. "1.2.3" or "2.0.0-rc.1+build.5",
+// without a leading v. The reason is "not a semantic version (expected MAJOR.MINOR.PATCH)".
+func IsSemver(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a semantic version", func(s string) string {
+		if !semverRegex.MatchString(s) {
+			return "expected MAJOR.MINOR.PATCH"
+		}
+		return ""
+	})
+}
+
+// currencyCodes are the active ISO 4217 currency codes.
+var currencyCodes = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD
+		CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP
+		GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW
+		KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN
+		NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL
+		SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES
+		VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XTS XUA XXX YER ZAR ZMW ZWG ZWL`) {
+		currencyCodes[code] = true
+	}
+}
+
+// IsCurrencyCode creates a matcher that checks if the value is an active ISO 4217 currency code in upper case,
+// e.g. "EUR". The reason is e.g. "not an ISO 4217 currency code (unknown code ABC)".
+func IsCurrencyCode(placeholder string) Matcher {
+	return stringMatcher(placeholder, "an ISO 4217 currency code", func(s string) string {
+		if !currencyCodes[s] {
+			return "unknown code " + s
+		}
+		return ""
+	})
+}
+
+// IsHex creates a matcher that checks if the value is a non-empty string of hexadecimal digits in either case.
+// The reason is e.g. "not a hex string (invalid character 'g' at index 3)".
+func IsHex(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a hex string", func(s string) string {
+		if s == "" {
+			return "empty"
+		}
+		for i := 0; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return fmt.Sprintf("invalid character %q at index %d", s[i], i)
+			}
+		}
+		return ""
+	})
+}
+
+// IsBase64 creates a matcher that checks if the value is a non-empty string of standard, padded base64.
+// The reason is e.g. "not a base64 string (illegal base64 data at input byte 4)".
+func IsBase64(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a base64 string", func(s string) string {
+		if s == "" {
+			return "empty"
+		}
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			return err.Error()
+		}
+		return ""
+	})
+}
+
+// IsULID creates a matcher that checks if the value is a ULID, 26 characters of Crockford's base32 in either case,
+// e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV". The reason is e.g. "not a ULID (invalid character 'U' at index 3)".
+func IsULID(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a ULID", func(s string) string {
+		if len(s) != 26 {
+			return fmt.Sprintf("length %d instead of 26", len(s))
+		}
+		for i := 0; i < len(s); i++ {
+			if !strings.ContainsRune("0123456789ABCDEFGHJKMNPQRSTVWXYZ", unicode.ToUpper(rune(s[i]))) {
+				return fmt.Sprintf("invalid character %q at index %d", s[i], i)
+			}
+		}
+		if s[0] > '7' {
+			return "timestamp out of range"
+		}
+		return ""
+	})
+}
+
+// maxKSUID is the largest KSUID, base62 encoded with digits before upper and lower case letters, like ASCII.
+const maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+// IsKSUID creates a matcher that checks if the value is a KSUID, 27 base62 characters,
+// e.g. "0ujtsYcgvSTl8PAuAdqWYSMnLOv". The reason is e.g. "not a KSUID (length 20 instead of 27)".
+func IsKSUID(placeholder string) Matcher {
+	return stringMatcher(placeholder, "a KSUID", func(s string) string {
+		if len(s) != 27 {
+			return fmt.Sprintf("length %d instead of 27", len(s))
+		}
+		for i := 0; i < len(s); i++ {
+			if c := s[i]; !isDigit(c) && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+				return fmt.Sprintf("invalid character %q at index %d", c, i)
+			}
+		}
+		if s > maxKSUID {
+			return "value out of range"
+		}
+		return ""
+	})
+}
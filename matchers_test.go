@@ -0,0 +1,188 @@
+package jman_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUUID_Reasons(t *testing.T) {
+	tests := map[string]struct {
+		value  any
+		reason string
+	}{
+		"valid":          {value: "550e8400-e29b-41d4-a716-446655440000"},
+		"upper case":     {value: "550E8400-E29B-41D4-A716-446655440000"},
+		"version 7":      {value: "018f4e2a-7b3c-7d4e-9f00-123456789abc", reason: "not a UUID (version nibble 7 out of range)"},
+		"variant":        {value: "550e8400-e29b-41d4-c716-446655440000", reason: "not a UUID (variant nibble c out of range)"},
+		"too short":      {value: "550e8400-e29b-41d4-a716", reason: "not a UUID (length 23 instead of 36)"},
+		"missing hyphen": {value: "550e8400xe29b-41d4-a716-446655440000", reason: `not a UUID ('x' instead of '-' at index 8)`},
+		"not hex":        {value: "550e8400-e29b-41d4-a716-44665544000g", reason: `not a UUID (invalid character 'g' at index 35)`},
+		"not a string":   {value: 42.0, reason: "not a UUID (number instead of string)"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := jman.IsUUID("$UUID").Match(tt.value)
+
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				assert.True(t, jman.IsUUID("$UUID").MatcherFunc(tt.value))
+				return
+			}
+			assert.EqualError(t, err, tt.reason)
+			assert.False(t, jman.IsUUID("$UUID").MatcherFunc(tt.value))
+		})
+	}
+}
+
+func TestEqualMatcher_Reasons(t *testing.T) {
+	m := jman.EqualMatcher("$STATUS", "active")
+
+	assert.NoError(t, m.Match("active"))
+	assert.EqualError(t, m.Match("deleted"), `expected "active"`)
+	assert.EqualError(t, m.Match(1.0), `expected string "active" - got float64`)
+}
+
+func TestCustom_NoReason(t *testing.T) {
+	m := jman.Custom("$POSITIVE", func(v any) bool {
+		n, ok := v.(float64)
+		return ok && n > 0
+	})
+
+	assert.NoError(t, m.Match(1.0))
+	assert.ErrorIs(t, m.Match(-1.0), jman.ErrNoMatch)
+
+	diffs := jman.Diff(t, jman.Obj{"n": "$POSITIVE"}, jman.Obj{"n": -1}, jman.WithMatchers(m))
+	assert.Equal(t, []string{`$.n expected value for placeholder "$POSITIVE" does not match actual value -1`}, diffStrings(diffs))
+}
+
+func TestCustomMatch_Reason(t *testing.T) {
+	m := jman.CustomMatch("$EVEN", func(v any) error {
+		if n, ok := v.(float64); ok && int(n)%2 == 0 {
+			return nil
+		}
+		return errors.New("not an even number")
+	})
+
+	diffs := jman.Diff(t, jman.Arr{"$EVEN", "$EVEN"}, jman.Arr{2, 3}, jman.WithMatchers(m))
+
+	assert.Equal(t, []string{`$.1 expected value for placeholder "$EVEN" does not match actual value 3: not an even number`}, diffStrings(diffs))
+}
+
+func TestEqual_MatcherReason(t *testing.T) {
+	expectedMsg := `expected not equal to actual:
+expected {"id":"$UUID"}
+actual {"id":"018f4e2a-7b3c-7d4e-9f00-123456789abc"}
+
+$.id expected value for placeholder "$UUID" does not match actual value 018f4e2a-7b3c-7d4e-9f00-123456789abc: not a UUID (version nibble 7 out of range)
+`
+
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, jman.Obj{"id": "$UUID"}, jman.Obj{"id": "018f4e2a-7b3c-7d4e-9f00-123456789abc"}, jman.WithMatchers(jman.IsUUID("$UUID")))
+	})
+}
+
+func TestNotEmpty_Reasons(t *testing.T) {
+	m := jman.NotEmpty("$ANY")
+
+	assert.NoError(t, m.Match(jman.Arr{1}))
+	assert.NoError(t, m.Match(jman.Obj{"a": nil}))
+	assert.NoError(t, m.Match(false))
+	assert.EqualError(t, m.Match(nil), "null")
+	assert.EqualError(t, m.Match(""), "empty string")
+	assert.EqualError(t, m.Match(jman.Arr{}), "empty array")
+	assert.EqualError(t, m.Match(jman.Obj{}), "empty object")
+}
+
+func TestMatchers_Library(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher jman.Matcher
+		value   any
+		reason  string
+	}{
+		{name: "string", matcher: jman.IsString("$"), value: ""},
+		{name: "string mismatch", matcher: jman.IsString("$"), value: 1.0, reason: "number instead of string"},
+		{name: "number", matcher: jman.IsNumber("$"), value: json.Number("1")},
+		{name: "number mismatch", matcher: jman.IsNumber("$"), value: "1", reason: "string instead of number"},
+		{name: "bool", matcher: jman.IsBool("$"), value: false},
+		{name: "bool mismatch", matcher: jman.IsBool("$"), value: nil, reason: "null instead of boolean"},
+		{name: "null", matcher: jman.IsNull("$"), value: nil},
+		{name: "null mismatch", matcher: jman.IsNull("$"), value: "", reason: "string instead of null"},
+		{name: "object", matcher: jman.IsObject("$"), value: jman.Obj{}},
+		{name: "object mismatch", matcher: jman.IsObject("$"), value: jman.Arr{}, reason: "array instead of object"},
+		{name: "array", matcher: jman.IsArray("$"), value: jman.Arr{}},
+		{name: "array mismatch", matcher: jman.IsArray("$"), value: jman.Obj{}, reason: "object instead of array"},
+
+		{name: "integer", matcher: jman.IsInteger("$"), value: 1e3},
+		{name: "integer json number", matcher: jman.IsInteger("$"), value: json.Number("9007199254740993")},
+		{name: "integer fraction", matcher: jman.IsInteger("$"), value: 1.5, reason: "not an integer (1.5)"},
+		{name: "integer string", matcher: jman.IsInteger("$"), value: "1", reason: "not an integer (string instead of number)"},
+		{name: "range", matcher: jman.InRange("$", 1, 10), value: 10.0},
+		{name: "range below", matcher: jman.InRange("$", 1, 10), value: 0.5, reason: "0.5 not between 1 and 10"},
+		{name: "range type", matcher: jman.InRange("$", 1, 10), value: true, reason: "boolean instead of number"},
+		{name: "length", matcher: jman.LenBetween("$", 1, 3), value: "äöü"},
+		{name: "length too long", matcher: jman.LenBetween("$", 1, 3), value: "abcd", reason: "length 4 not between 1 and 3"},
+		{name: "length array", matcher: jman.LenBetween("$", 1, 3), value: jman.Arr{1.0, 2.0}},
+		{name: "length empty array", matcher: jman.LenBetween("$", 1, 3), value: jman.Arr{}, reason: "length 0 not between 1 and 3"},
+		{name: "length object", matcher: jman.LenBetween("$", 1, 3), value: jman.Obj{"a": 1.0}},
+		{name: "length object too large", matcher: jman.LenBetween("$", 0, 1), value: jman.Obj{"a": 1.0, "b": 2.0}, reason: "length 2 not between 0 and 1"},
+		{name: "length of a number", matcher: jman.LenBetween("$", 1, 3), value: 1.0, reason: "number has no length"},
+		{name: "prefix", matcher: jman.HasPrefix("$", "ord_"), value: "ord_1"},
+		{name: "prefix mismatch", matcher: jman.HasPrefix("$", "ord_"), value: "inv_1", reason: `does not start with "ord_"`},
+		{name: "suffix", matcher: jman.HasSuffix("$", ".png"), value: "a.png"},
+		{name: "suffix mismatch", matcher: jman.HasSuffix("$", ".png"), value: "a.jpg", reason: `does not end with ".png"`},
+		{name: "substring", matcher: jman.HasSubstring("$", "@"), value: "a@b"},
+		{name: "substring mismatch", matcher: jman.HasSubstring("$", "@"), value: 1.0, reason: "number instead of string"},
+		{name: "regex", matcher: jman.MatchesRegex("$", `^\d+$`), value: "123"},
+		{name: "regex mismatch", matcher: jman.MatchesRegex("$", `^\d+$`), value: "12a", reason: `does not match regular expression "^\\d+$"`},
+
+		{name: "rfc3339", matcher: jman.IsRFC3339("$"), value: "2024-05-01T12:30:00.123+02:00"},
+		{name: "rfc3339 range", matcher: jman.IsRFC3339("$"), value: "2024-13-01T12:30:00Z", reason: "not an RFC 3339 timestamp (month out of range)"},
+		{name: "rfc3339 format", matcher: jman.IsRFC3339("$"), value: "2024-05-01 12:30", reason: "not an RFC 3339 timestamp (expected the format 2006-01-02T15:04:05Z07:00)"},
+		{name: "date", matcher: jman.IsDate("$"), value: "2024-02-29"},
+		{name: "date range", matcher: jman.IsDate("$"), value: "2023-02-29", reason: "not a date (day out of range)"},
+		{name: "email", matcher: jman.IsEmail("$"), value: "alice@example.com"},
+		{name: "email no at", matcher: jman.IsEmail("$"), value: "alice.example.com", reason: "not an email address (no @)"},
+		{name: "email display name", matcher: jman.IsEmail("$"), value: "Alice <alice@example.com>", reason: "not an email address (not a bare address)"},
+		{name: "url", matcher: jman.IsURL("$"), value: "https://example.com/a?b=c"},
+		{name: "url relative", matcher: jman.IsURL("$"), value: "/a/b", reason: "not a URL (no scheme)"},
+		{name: "url no host", matcher: jman.IsURL("$"), value: "mailto:alice@example.com", reason: "not a URL (no host)"},
+		{name: "ipv4", matcher: jman.IsIPv4("$"), value: "192.0.2.1"},
+		{name: "ipv4 given ipv6", matcher: jman.IsIPv4("$"), value: "2001:db8::1", reason: "not an IPv4 address (IPv6 address)"},
+		{name: "ipv4 invalid", matcher: jman.IsIPv4("$"), value: "256.0.0.1", reason: "not an IPv4 address (invalid address)"},
+		{name: "ipv6", matcher: jman.IsIPv6("$"), value: "2001:db8::1"},
+		{name: "ipv6 given ipv4", matcher: jman.IsIPv6("$"), value: "192.0.2.1", reason: "not an IPv6 address (IPv4 address)"},
+		{name: "semver", matcher: jman.IsSemver("$"), value: "2.0.0-rc.1+build.5"},
+		{name: "semver prefix", matcher: jman.IsSemver("$"), value: "v1.2.3", reason: "not a semantic version (expected MAJOR.MINOR.PATCH)"},
+		{name: "currency", matcher: jman.IsCurrencyCode("$"), value: "EUR"},
+		{name: "currency unknown", matcher: jman.IsCurrencyCode("$"), value: "eur", reason: "not an ISO 4217 currency code (unknown code eur)"},
+		{name: "hex", matcher: jman.IsHex("$"), value: "deadBEEF"},
+		{name: "hex invalid", matcher: jman.IsHex("$"), value: "abcg", reason: "not a hex string (invalid character 'g' at index 3)"},
+		{name: "hex empty", matcher: jman.IsHex("$"), value: "", reason: "not a hex string (empty)"},
+		{name: "base64", matcher: jman.IsBase64("$"), value: "aGVsbG8="},
+		{name: "base64 invalid", matcher: jman.IsBase64("$"), value: "aGVsbG8", reason: "not a base64 string (illegal base64 data at input byte 4)"},
+		{name: "ulid", matcher: jman.IsULID("$"), value: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "ulid invalid", matcher: jman.IsULID("$"), value: "01AUZ3NDEKTSV4RRFFQ69G5FAV", reason: "not a ULID (invalid character 'U' at index 3)"},
+		{name: "ulid overflow", matcher: jman.IsULID("$"), value: "81ARZ3NDEKTSV4RRFFQ69G5FAV", reason: "not a ULID (timestamp out of range)"},
+		{name: "ksuid", matcher: jman.IsKSUID("$"), value: "0ujtsYcgvSTl8PAuAdqWYSMnLOv"},
+		{name: "ksuid length", matcher: jman.IsKSUID("$"), value: "0ujtsYcgvSTl8PAuAdqW", reason: "not a KSUID (length 20 instead of 27)"},
+		{name: "ksuid overflow", matcher: jman.IsKSUID("$"), value: "zzzzzzzzzzzzzzzzzzzzzzzzzzz", reason: "not a KSUID (value out of range)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.matcher.Match(tt.value)
+
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.reason)
+		})
+	}
+}
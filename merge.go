@@ -0,0 +1,83 @@
+package jman
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Merge applies an RFC 7386 JSON Merge Patch to the Obj in place.
+// The patch can be a JSON string, byte slice or Obj, like in New. Keys with a null value
+// in the patch are removed, nested objects are merged recursively and any other value,
+// including arrays, replaces the existing value. The result is normalized.
+func (o Obj) Merge(t T, patch any) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.MergeE(patch); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// MergeE applies an RFC 7386 JSON Merge Patch to the Obj in place like Merge, but returns an error instead of failing a test.
+func (o Obj) MergeE(patch any) error {
+	p, err := NewE[Obj](patch)
+	if err != nil {
+		return err
+	}
+	normed, err := normalize(o)
+	if err != nil {
+		return fmt.Errorf("%w %T: %v", ErrNormalize, o, err)
+	}
+
+	merged := mergePatch(clone(normed), p).(Obj)
+	clear(o)
+	maps.Copy(o, merged)
+	return nil
+}
+
+// DeepMerge layers several JSON objects on top of each other and returns the result.
+// Each document can be a JSON string, byte slice or Obj, like in New, and is applied
+// to the result of the previous ones as a JSON Merge Patch (see Obj.Merge).
+// None of the documents are modified.
+func DeepMerge(t T, docs ...any) Obj {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	result, err := DeepMergeE(docs...)
+	if err != nil {
+		t.Fatalf(err.Error())
+		return nil
+	}
+	return result
+}
+
+// DeepMergeE layers several JSON objects on top of each other like DeepMerge, but returns an error instead of failing a test.
+func DeepMergeE(docs ...any) (Obj, error) {
+	result := Obj{}
+	for _, doc := range docs {
+		if err := result.MergeE(doc); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(Obj)
+	if !ok {
+		return clone(patch)
+	}
+
+	targetObj, ok := target.(Obj)
+	if !ok {
+		targetObj = Obj{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = mergePatch(targetObj[k], v)
+	}
+	return targetObj
+}
@@ -0,0 +1,87 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObj_Merge(t *testing.T) {
+	testCases := []struct {
+		name  string
+		data  jman.Obj
+		patch any
+		want  jman.Obj
+	}{
+		{
+			name:  "ReplaceValue",
+			data:  jman.Obj{"a": "b"},
+			patch: `{"a":"c"}`,
+			want:  jman.Obj{"a": "c"},
+		},
+		{
+			name:  "AddValue",
+			data:  jman.Obj{"a": "b"},
+			patch: []byte(`{"b":"c"}`),
+			want:  jman.Obj{"a": "b", "b": "c"},
+		},
+		{
+			name:  "NullRemoves",
+			data:  jman.Obj{"a": "b", "b": "c"},
+			patch: jman.Obj{"a": nil},
+			want:  jman.Obj{"b": "c"},
+		},
+		{
+			name:  "ArraysReplace",
+			data:  jman.Obj{"a": jman.Arr{1, 2}},
+			patch: jman.Obj{"a": jman.Arr{3}},
+			want:  jman.Obj{"a": jman.Arr{3}},
+		},
+		{
+			name:  "NestedObjectsMerge",
+			data:  jman.Obj{"a": jman.Obj{"b": 1, "c": 2}},
+			patch: jman.Obj{"a": jman.Obj{"c": nil, "d": jman.Obj{"e": nil, "f": 3}}},
+			want:  jman.Obj{"a": jman.Obj{"b": 1, "d": jman.Obj{"f": 3}}},
+		},
+		{
+			name:  "ObjectReplacesScalar",
+			data:  jman.Obj{"a": "b"},
+			patch: jman.Obj{"a": jman.Obj{"c": 1}},
+			want:  jman.Obj{"a": jman.Obj{"c": 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data
+			data.Merge(t, tc.patch)
+			data.Equal(t, tc.want)
+		})
+	}
+}
+
+func TestObj_Merge_InvalidPatch(t *testing.T) {
+	data := jman.Obj{"a": "b"}
+	assertFatalf(t, `error parsing JSON data ["a"]: json: cannot unmarshal array into Go value of type map[string]interface {}`, func(mt jman.T) {
+		data.Merge(mt, `["a"]`)
+	})
+}
+
+func TestDeepMerge(t *testing.T) {
+	base := jman.Obj{
+		"name":    "alice",
+		"address": jman.Obj{"city": "Berlin", "zip": "10115"},
+		"roles":   jman.Arr{"user"},
+	}
+	override := `{"address":{"zip":"10117"},"roles":["admin"]}`
+
+	merged := jman.DeepMerge(t, base, override, jman.Obj{"name": nil})
+
+	assert.Equal(t, jman.Obj{
+		"address": jman.Obj{"city": "Berlin", "zip": "10117"},
+		"roles":   jman.Arr{"admin"},
+	}, merged)
+	assert.Equal(t, "Berlin", base.GetString(t, "$.address.city"))
+	assert.Equal(t, "10115", base.GetString(t, "$.address.zip"))
+}
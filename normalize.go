@@ -0,0 +1,474 @@
+package jman
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxNormalizeDepth is the depth of pointers and containers beyond which values are normalized
+// through encoding/json, which detects cycles and fails on them.
+const maxNormalizeDepth = 1000
+
+var (
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	numberType        = reflect.TypeFor[json.Number]()
+)
+
+// normalize converts data into the value a json.Marshal and json.Unmarshal round trip would produce,
+// where every nested value is either nil, bool, float64, string, Obj or Arr, except that json.Number
+// values are kept. It converts values in memory and only falls back to encoding/json for values with
+// their own JSON encoding and structs it can't encode the same way. Subtrees that are already normalized
+// are shared with data rather than copied, so the result must be cloned before it is modified, unless
+// data is modified too.
+func normalize[T JSONEqual](data T) (T, error) {
+	return normalizeNumbers(data, keepNumbers)
+}
+
+// normalizeNumbers normalizes data like normalize, with numbers represented as given by the number mode.
+func normalizeNumbers[T JSONEqual](data T, numbers numberMode) (T, error) {
+	var (
+		normalized any
+		err        error
+	)
+	switch d := any(data).(type) {
+	case Obj:
+		if d == nil {
+			// a round trip turns the null of a nil Obj into an empty one
+			return any(Obj{}).(T), nil
+		}
+		normalized, _, err = normalizeObj(d, 0, numbers)
+	case Arr:
+		if len(d) == 0 {
+			// a round trip appends nothing to a nil Arr
+			return any(Arr(nil)).(T), nil
+		}
+		normalized, _, err = normalizeArr(d, 0, numbers)
+	case Val:
+		var value any
+		value, _, err = normalizeAny(d.Value, 0, numbers)
+		normalized = Val{Value: value}
+	default:
+		return normalizeJSON(data)
+	}
+	if err != nil {
+		return data, fmt.Errorf("error marshalling JSON object: %w", err)
+	}
+	return normalized.(T), nil
+}
+
+// normalizeJSON normalizes data through a json.Marshal and json.Unmarshal round trip.
+func normalizeJSON[T JSONEqual](data T) (T, error) {
+	marshaled, err := json.Marshal(data)
+	if err != nil {
+		return data, fmt.Errorf("error marshalling JSON object: %w", err)
+	}
+
+	var normalized T
+	if err := json.Unmarshal(marshaled, &normalized); err != nil {
+		return data, fmt.Errorf("error unmarshalling JSON object: %w", err)
+	}
+
+	return normalized, nil
+}
+
+// normalizeAny normalizes a nested value and reports whether it had to be changed to do so.
+func normalizeAny(v any, depth int, numbers numberMode) (any, bool, error) {
+	switch typed := v.(type) {
+	case nil, bool:
+		return v, false, nil
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			// encoding/json can't encode these, so this returns its error
+			normalized, err := roundTrip(typed, numbers)
+			return normalized, true, err
+		}
+		if numbers == exactNumbers {
+			return json.Number(formatFloat(typed, 64)), true, nil
+		}
+		return v, false, nil
+	case json.Number:
+		if numbers == floatNumbers || !validNumber(typed) {
+			// encoding/json reports invalid numbers and numbers out of the range of a float64
+			normalized, err := roundTrip(typed, numbers)
+			return normalized, true, err
+		}
+		return v, false, nil
+	case string:
+		if utf8.ValidString(typed) {
+			return v, false, nil
+		}
+		normalized, err := roundTrip(typed, numbers)
+		return normalized, true, err
+	case Obj:
+		if typed == nil {
+			return nil, true, nil
+		}
+		normalized, changed, err := normalizeObj(typed, depth, numbers)
+		if !changed {
+			return v, false, err
+		}
+		return normalized, true, err
+	case Arr:
+		if typed == nil {
+			return nil, true, nil
+		}
+		normalized, changed, err := normalizeArr(typed, depth, numbers)
+		if !changed {
+			return v, false, err
+		}
+		return normalized, true, err
+	case map[string]any:
+		if typed == nil {
+			return nil, true, nil
+		}
+		normalized, _, err := normalizeObj(Obj(typed), depth, numbers)
+		return normalized, true, err
+	case []any:
+		if typed == nil {
+			return nil, true, nil
+		}
+		normalized, _, err := normalizeArr(Arr(typed), depth, numbers)
+		return normalized, true, err
+	}
+	normalized, err := normalizeReflect(reflect.ValueOf(v), depth, numbers)
+	return normalized, true, err
+}
+
+// normalizeObj normalizes the values of obj, copying it only once a value has to be changed.
+func normalizeObj(obj Obj, depth int, numbers numberMode) (Obj, bool, error) {
+	if depth > maxNormalizeDepth {
+		normalized, err := roundTrip(obj, numbers)
+		if err != nil {
+			return nil, false, err
+		}
+		return normalized.(Obj), true, nil
+	}
+	var normalized Obj
+	for k, v := range obj {
+		value, changed, err := normalizeAny(v, depth+1, numbers)
+		if err != nil {
+			return nil, false, err
+		}
+		key := k
+		if !utf8.ValidString(k) {
+			if key, err = normalizeKey(k); err != nil {
+				return nil, false, err
+			}
+			changed = true
+		}
+		if changed && normalized == nil {
+			normalized = maps.Clone(obj)
+		}
+		if normalized != nil {
+			if key != k {
+				delete(normalized, k)
+			}
+			normalized[key] = value
+		}
+	}
+	if normalized == nil {
+		return obj, false, nil
+	}
+	return normalized, true, nil
+}
+
+// normalizeArr normalizes the items of arr, copying it only once an item has to be changed.
+func normalizeArr(arr Arr, depth int, numbers numberMode) (Arr, bool, error) {
+	if depth > maxNormalizeDepth {
+		normalized, err := roundTrip(arr, numbers)
+		if err != nil {
+			return nil, false, err
+		}
+		return normalized.(Arr), true, nil
+	}
+	var normalized Arr
+	for i, v := range arr {
+		value, changed, err := normalizeAny(v, depth+1, numbers)
+		if err != nil {
+			return nil, false, err
+		}
+		if changed && normalized == nil {
+			normalized = make(Arr, len(arr))
+			copy(normalized, arr[:i])
+		}
+		if normalized != nil {
+			normalized[i] = value
+		}
+	}
+	if normalized == nil {
+		return arr, false, nil
+	}
+	return normalized, true, nil
+}
+
+func normalizeReflect(rv reflect.Value, depth int, numbers numberMode) (any, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if depth > maxNormalizeDepth {
+		return roundTrip(rv.Interface(), numbers)
+	}
+
+	t := rv.Type()
+	if t.Implements(marshalerType) || t.Implements(textMarshalerType) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, nil
+		}
+		return roundTrip(rv.Interface(), numbers)
+	}
+	if rv.CanAddr() && (reflect.PointerTo(t).Implements(marshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return roundTrip(rv.Addr().Interface(), numbers)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if numbers == exactNumbers {
+			return json.Number(strconv.FormatInt(rv.Int(), 10)), nil
+		}
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if numbers == exactNumbers {
+			return json.Number(strconv.FormatUint(rv.Uint(), 10)), nil
+		}
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return roundTrip(rv.Interface(), numbers)
+		}
+		if numbers == exactNumbers {
+			return json.Number(formatFloat(f, t.Bits())), nil
+		}
+		if rv.Kind() == reflect.Float32 {
+			// encoding/json writes float32 values with the fewest digits that identify them as a float32
+			return strconv.ParseFloat(strconv.FormatFloat(f, 'g', -1, 32), 64)
+		}
+		return f, nil
+	case reflect.String:
+		if t == numberType {
+			normalized, _, err := normalizeAny(json.Number(rv.String()), depth, numbers)
+			return normalized, err
+		}
+		normalized, _, err := normalizeAny(rv.String(), depth, numbers)
+		return normalized, err
+	case reflect.Interface, reflect.Pointer:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Interface && rv.Elem().CanInterface() {
+			normalized, _, err := normalizeAny(rv.Elem().Interface(), depth+1, numbers)
+			return normalized, err
+		}
+		return normalizeReflect(rv.Elem(), depth+1, numbers)
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		normalized := make(Obj, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := reflectKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := normalizeReflect(iter.Value(), depth+1, numbers)
+			if err != nil {
+				return nil, err
+			}
+			normalized[key] = value
+		}
+		return normalized, nil
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are encoded as base64 strings
+			return roundTrip(rv.Interface(), numbers)
+		}
+		fallthrough
+	case reflect.Array:
+		normalized := make(Arr, rv.Len())
+		for i := range normalized {
+			value, err := normalizeReflect(rv.Index(i), depth+1, numbers)
+			if err != nil {
+				return nil, err
+			}
+			normalized[i] = value
+		}
+		return normalized, nil
+	case reflect.Struct:
+		fields, ok := structFieldsFor(t)
+		if !ok {
+			return roundTrip(rv.Interface(), numbers)
+		}
+		normalized := make(Obj, len(fields))
+		for _, f := range fields {
+			fv := rv.Field(f.index)
+			if f.omitEmpty && isEmptyValue(fv) {
+				continue
+			}
+			value, err := normalizeReflect(fv, depth+1, numbers)
+			if err != nil {
+				return nil, err
+			}
+			normalized[f.name] = value
+		}
+		return normalized, nil
+	}
+
+	// channels, functions and complex numbers can't be encoded, so this returns the error of encoding/json
+	return roundTrip(rv.Interface(), numbers)
+}
+
+// reflectKey converts a map key to an object key the way encoding/json does.
+func reflectKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return normalizeKey(k.String())
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return normalizeKey(string(text))
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	_, err := json.Marshal(map[any]any{k.Interface(): nil})
+	return "", err
+}
+
+// normalizeKey replaces invalid UTF-8 in an object key like encoding/json does.
+func normalizeKey(k string) (string, error) {
+	if utf8.ValidString(k) {
+		return k, nil
+	}
+	normalized, err := roundTrip(k, keepNumbers)
+	if err != nil {
+		return "", err
+	}
+	return normalized.(string), nil
+}
+
+// roundTrip normalizes v through encoding/json.
+func roundTrip(v any, numbers numberMode) (any, error) {
+	marshaled, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := decodeJSON(marshaled, &normalized, numbers); err != nil {
+		return nil, err
+	}
+	return convert(normalized), nil
+}
+
+type structField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// structFields caches the fields of struct types, or nil for structs that are normalized through encoding/json.
+var structFields sync.Map
+
+// structFieldsFor returns the fields of a struct type that encoding/json encodes. It returns false for structs
+// with embedded fields, duplicate names or tag options other than omitempty, which are left to encoding/json.
+func structFieldsFor(t reflect.Type) ([]structField, bool) {
+	if cached, ok := structFields.Load(t); ok {
+		fields := cached.([]structField)
+		return fields, fields != nil
+	}
+
+	var (
+		fields []structField
+		names  = map[string]bool{}
+		simple = true
+	)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous {
+			simple = false
+			break
+		}
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if opts != "" && opts != "omitempty" {
+			simple = false
+			break
+		}
+		if name == "" {
+			name = f.Name
+		} else if !validTagName(name) {
+			simple = false
+			break
+		}
+		if names[name] {
+			simple = false
+			break
+		}
+		names[name] = true
+		fields = append(fields, structField{index: i, name: name, omitEmpty: opts == "omitempty"})
+	}
+	if !simple {
+		fields = nil
+	} else if fields == nil {
+		fields = []structField{}
+	}
+
+	structFields.Store(t, fields)
+	return fields, fields != nil
+}
+
+// validTagName reports whether encoding/json accepts the name in a struct tag.
+func validTagName(name string) bool {
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyValue reports whether a field with the omitempty option is left out by encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
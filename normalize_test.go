@@ -0,0 +1,104 @@
+package jman_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Street string `json:"street"`
+	Zip    *int   `json:"zip,omitempty"`
+}
+
+type base struct {
+	ID int `json:"id"`
+}
+
+type user struct {
+	Name     string            `json:"name"`
+	Age      uint8             `json:"age"`
+	Score    float32           `json:"score"`
+	Tags     []string          `json:"tags"`
+	Address  *address          `json:"address"`
+	Extra    map[string]any    `json:"extra,omitempty"`
+	Counts   map[int]int       `json:"counts"`
+	Internal string            `json:"-"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	secret   string
+	Untagged bool
+}
+
+type embedded struct {
+	base
+	Count int64 `json:"count,string"`
+}
+
+type celsius float64
+
+func (c celsius) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%.1fC"`, float64(c))), nil
+}
+
+func TestNew_NormalizesLikeJSON(t *testing.T) {
+	zip := 12345
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	tests := map[string]any{
+		"numbers":        jman.Obj{"int": 1, "int64": int64(-7), "uint": uint(3), "float32": float32(0.1), "float64": 0.1},
+		"struct":         jman.Obj{"user": user{Name: "alice", Age: 30, Score: 1.1, Tags: []string{"a"}, Address: &address{Street: "main", Zip: &zip}, Counts: map[int]int{2: 4}, secret: "x"}},
+		"empty struct":   jman.Obj{"user": user{}},
+		"embedded":       jman.Obj{"value": embedded{base: base{ID: 1}, Count: 2}},
+		"marshalers":     jman.Obj{"at": at, "at pointer": &at, "temperature": celsius(21.5), "number": json.Number("1.50")},
+		"nils":           jman.Obj{"pointer": (*address)(nil), "map": map[string]int(nil), "slice": []int(nil), "obj": jman.Obj(nil), "arr": jman.Arr(nil)},
+		"collections":    jman.Obj{"bytes": []byte("hello"), "array": [2]bool{true, false}, "nested": []any{map[string]any{"a": []int{1}}}},
+		"invalid utf8":   jman.Obj{"key\xff": "value\xfe"},
+		"normalized":     jman.Obj{"a": jman.Obj{"b": jman.Arr{1.0, "c", nil, true}}},
+		"array of mixed": jman.Arr{1, "a", user{Name: "bob"}, jman.Arr{uint16(2)}},
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			marshaled, err := json.Marshal(value)
+			assert.NoError(t, err)
+
+			switch v := value.(type) {
+			case jman.Obj:
+				assert.Equal(t, jman.New[jman.Obj](t, marshaled), jman.New[jman.Obj](t, v))
+			case jman.Arr:
+				assert.Equal(t, jman.New[jman.Arr](t, marshaled), jman.New[jman.Arr](t, v))
+			}
+		})
+	}
+}
+
+func TestNew_NormalizeError(t *testing.T) {
+	assertFatalf(t, "error normalizing JSON data jman.Obj: error marshalling JSON object: json: unsupported value: NaN", func(mt jman.T) {
+		jman.New[jman.Obj](mt, jman.Obj{"a": jman.Arr{math.NaN()}})
+	})
+}
+
+func TestNew_DoesNotShareData(t *testing.T) {
+	nested := jman.Obj{"b": 1.0}
+	original := jman.Obj{"a": nested}
+
+	obj := jman.New[jman.Obj](t, original)
+	obj.Set(t, "$.a.b", 2)
+
+	assert.Equal(t, 1.0, nested["b"])
+}
+
+func BenchmarkObj_Get(b *testing.B) {
+	doc := jman.Obj{"items": make(jman.Arr, 1000)}
+	for i := range 1000 {
+		doc["items"].(jman.Arr)[i] = jman.Obj{"id": float64(i), "name": fmt.Sprintf("item-%d", i), "tags": jman.Arr{"a", "b"}}
+	}
+
+	for range b.N {
+		doc.Get(b, "$.items.500.name")
+	}
+}
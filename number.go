@@ -0,0 +1,120 @@
+package jman
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+)
+
+// numberMode is how numbers are represented in normalized values.
+type numberMode int
+
+const (
+	// keepNumbers converts numbers to float64, but keeps json.Number values as they are.
+	keepNumbers numberMode = iota
+	// floatNumbers converts every number to float64, like json.Unmarshal.
+	floatNumbers
+	// exactNumbers converts every number to json.Number, like a json.Decoder with UseNumber.
+	exactNumbers
+)
+
+// decodeJSON unmarshals data into v like json.Unmarshal, but with numbers as json.Number in exactNumbers mode.
+func decodeJSON(data []byte, v any, numbers numberMode) error {
+	if numbers != exactNumbers || !json.Valid(data) {
+		// json.Unmarshal also reports the syntax errors, including data after the value, the decoder wouldn't
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// formatFloat formats a float of the given bit size the way encoding/json does.
+func formatFloat(f float64, bits int) string {
+	var v any = f
+	if bits == 32 {
+		v = float32(f)
+	}
+	// NaN and infinities are handled before, so marshaling can't fail
+	marshaled, _ := json.Marshal(v)
+	return string(marshaled)
+}
+
+// validNumber reports whether n is a number in JSON syntax.
+func validNumber(n json.Number) bool {
+	if n == "" {
+		return false
+	}
+	first, last := n[0], n[len(n)-1]
+	return (first == '-' || isDigit(first)) && isDigit(last) && json.Valid([]byte(n))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// exactValue returns the exact value of a number, or nil if it is not a number.
+func exactValue(v any) *big.Rat {
+	switch typed := v.(type) {
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			return nil
+		}
+		return new(big.Rat).SetFloat64(typed)
+	case json.Number:
+		r, ok := new(big.Rat).SetString(typed.String())
+		if !ok {
+			return nil
+		}
+		return r
+	}
+	return nil
+}
+
+// floatValue returns the closest float64 to a number.
+func floatValue(v any) float64 {
+	switch typed := v.(type) {
+	case float64:
+		return typed
+	case json.Number:
+		// numbers out of range are rounded to an infinity along with the error
+		f, _ := typed.Float64()
+		return f
+	}
+	return math.NaN()
+}
+
+// numbersEqual reports whether two numbers of the same type have the same value,
+// so that json.Number values like 1.0 and 1 are equal.
+func numbersEqual(expected, actual any) bool {
+	if e, ok := expected.(float64); ok {
+		return e == actual
+	}
+	e, a := exactValue(expected), exactValue(actual)
+	return e != nil && a != nil && e.Cmp(a) == 0
+}
+
+// exceedsTolerance returns the difference between two numbers of the same type and whether it is greater
+// than the tolerance. json.Number values are subtracted exactly, so that integers too large for a float64,
+// like 9007199254740993 and 9007199254740992, don't round to the same value.
+func exceedsTolerance(expected, actual any, tolerance float64) (float64, bool) {
+	_, isNumber := expected.(json.Number)
+	e, a := exactValue(expected), exactValue(actual)
+	if !isNumber || e == nil || a == nil || math.IsInf(tolerance, 0) {
+		delta := math.Abs(floatValue(expected) - floatValue(actual))
+		return delta, delta > tolerance
+	}
+	exact := new(big.Rat).Abs(new(big.Rat).Sub(e, a))
+	delta, _ := exact.Float64()
+	return delta, exact.Cmp(new(big.Rat).SetFloat64(tolerance)) > 0
+}
+
+// integerValue returns the value of a number if it is an integer.
+func integerValue(v any) (*big.Int, bool) {
+	r := exactValue(v)
+	if r == nil || !r.IsInt() {
+		return nil, false
+	}
+	return new(big.Int).Set(r.Num()), true
+}
@@ -0,0 +1,88 @@
+package jman_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_UseNumber_LargeIntegers(t *testing.T) {
+	expected := `{"id": 1790301431712169985, "ids": [9007199254740993]}`
+	actual := `{"id": 1790301431712169984, "ids": [9007199254740992]}`
+
+	assert.Empty(t, jman.Diff(t, expected, actual))
+
+	diffs := jman.Diff(t, expected, actual, jman.WithUseNumber())
+	assert.ElementsMatch(t, []string{
+		"$.id expected 1790301431712169985 - actual 1790301431712169984",
+		"$.ids.0 expected 9007199254740993 - actual 9007199254740992",
+	}, diffStrings(diffs))
+}
+
+func TestEqual_UseNumber_GoValues(t *testing.T) {
+	expected := jman.Obj{
+		"id":     int64(1790301431712169985),
+		"count":  uint64(18446744073709551615),
+		"price":  19.99,
+		"ratio":  float32(0.1),
+		"number": json.Number("1.50"),
+	}
+	actual := `{"id": 1790301431712169985, "count": 18446744073709551615, "price": 19.990, "ratio": 1e-1, "number": 1.5}`
+
+	jman.Equal(t, expected, actual, jman.WithUseNumber())
+}
+
+func TestEqual_UseNumber_IgnoreOrder(t *testing.T) {
+	expected := `[{"id": 1790301431712169985}, {"id": 1790301431712169986}]`
+	actual := `[{"id": 1790301431712169986.0}, {"id": 1790301431712169985}]`
+
+	jman.Equal(t, expected, actual, jman.WithUseNumber(), jman.WithIgnoreArrayOrder("$"))
+}
+
+func TestEqual_UseNumber_FloatTolerance(t *testing.T) {
+	diffs := jman.Diff(t, `{"total": 0.3}`, `{"total": 0.31}`, jman.WithUseNumber(), jman.WithFloatTolerance(0.001))
+
+	assert.Equal(t, []string{"$.total expected 0.3 - actual 0.31, delta 0.01 exceeds tolerance 0.001"}, diffStrings(diffs))
+}
+
+func TestNew_UseNumber(t *testing.T) {
+	obj := jman.New[jman.Obj](t, `{"id": 1790301431712169985, "items": [{"n": 2.5}]}`, jman.WithUseNumber())
+
+	assert.Equal(t, jman.Obj{
+		"id":    json.Number("1790301431712169985"),
+		"items": jman.Arr{jman.Obj{"n": json.Number("2.5")}},
+	}, obj)
+	assert.Equal(t, int64(1790301431712169985), obj.GetInt64(t, "$.id"))
+	assert.Equal(t, 2.5, obj.GetNumber(t, "$.items.0.n"))
+	assert.Equal(t, jman.Arr{jman.Obj{"n": json.Number("2.5")}}, obj.GetAll(t, "$.items[?(@.n > 2)]"))
+
+	arr := jman.New[jman.Arr](t, []byte(`[18446744073709551617]`), jman.WithUseNumber())
+	expected, _ := new(big.Int).SetString("18446744073709551617", 10)
+	assert.Equal(t, expected, arr.GetBigInt(t, "$.0"))
+}
+
+func TestNew_UseNumber_InvalidJSON(t *testing.T) {
+	_, err := jman.NewE[jman.Obj](`{"id": 1} {}`, jman.WithUseNumber())
+	assert.EqualError(t, err, `error parsing JSON data {"id": 1} {}: invalid character '{' after top-level value`)
+
+	_, err = jman.NewE[jman.Obj](`[1]`, jman.WithUseNumber())
+	assert.EqualError(t, err, `error parsing JSON data [1]: json: cannot unmarshal array into Go value of type map[string]interface {}`)
+}
+
+func TestObj_GetInt64(t *testing.T) {
+	obj := jman.Obj{"small": 42, "float": 1.5, "big": 1e19, "name": "x"}
+
+	assert.Equal(t, int64(42), obj.GetInt64(t, "$.small"))
+	assertFatalf(t, "expected integer at path '$.float', got float64 (1.5)", func(mt jman.T) {
+		obj.GetInt64(mt, "$.float")
+	})
+	assertFatalf(t, "expected int64 at path '$.big', got 10000000000000000000 which is out of range", func(mt jman.T) {
+		obj.GetInt64(mt, "$.big")
+	})
+	assertFatalf(t, "expected integer at path '$.name', got string (x)", func(mt jman.T) {
+		obj.GetBigInt(mt, "$.name")
+	})
+}
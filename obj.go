@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math/big"
+	"slices"
 )
 
 // Obj represents a JSON object. It implements the Equaler interface for deep equality checks.
@@ -13,8 +15,12 @@ type Obj map[string]any
 // will be converted to Arr or Obj, and other simple types will be converted to their
 // corresponding types (bool, string, float64).
 func (o *Obj) UnmarshalJSON(data []byte) error {
+	return o.unmarshal(data, floatNumbers)
+}
+
+func (o *Obj) unmarshal(data []byte, numbers numberMode) error {
 	raw := map[string]any{}
-	if err := json.Unmarshal(data, &raw); err != nil {
+	if err := decodeJSON(data, &raw, numbers); err != nil {
 		return err
 	}
 
@@ -46,76 +52,57 @@ func convert(v any) any {
 
 // Equal checks if the Obj is equal to another value, which can be either a JSON string, byte slice, or another Obj.
 func (ob Obj) Equal(t T, other any, optFuncs ...optsFunc) {
-	opts := equalOptions{}
-
-	for _, o := range optFuncs {
-		o(&opts)
-	}
-
-	if err := opts.valid(); err != nil {
-		t.Fatalf(fmt.Sprintf("invalid options: %v", err))
-		return
-	}
-
-	// check if other value is an array
-	switch v := other.(type) {
-	case string:
-		if v[0] == '[' {
-			t.Fatalf("can't compare json object with array")
-		}
-	case []byte:
-		if v[0] == '[' {
-			t.Fatalf("can't compare json object with array")
-		}
-	case Arr:
-		t.Fatalf("can't compare json object with array")
-	}
-
-	act := New[Obj](t, other)
-
-	ob, err := normalize(ob)
-	if err != nil {
-		t.Fatalf(fmt.Sprintf("expected is invalid json: %v", err))
-	}
-
-	diffs := compareObjects(base, ob, act, opts)
-	if len(diffs) > 0 {
-		t.Fatalf(fmt.Sprintf("expected not equal to actual:\nexpected %s\nactual %s\n\n%s", ob.String(t), act.String(t), diffs.report()))
+	if h, ok := t.(helperT); ok {
+		h.Helper()
 	}
+	Equal(t, ob, other, optFuncs...)
 }
 
-func compareObjects(path string, expected, actual Obj, opts equalOptions) differences {
+func compareObjects(path *location, expected, actual Obj, opts equalOptions) differences {
 	var diffs differences
 	for k := range maps.Keys(expected) {
+		if opts.ignored(path.child(k)) {
+			continue
+		}
 		_, exists := actual[k]
 		if !exists {
 			diffs = append(diffs, difference{
-				diff: "not found in actual",
-				path: pathAndKey(path, k),
+				diff:     "not found in actual",
+				path:     path.child(k),
+				kind:     DiffMissing,
+				expected: expected[k],
 			})
 		}
 	}
 
-	for k := range maps.Keys(actual) {
-		_, exists := expected[k]
-		if !exists {
-			diffs = append(diffs, difference{
-				diff: "unexpected key",
-				path: pathAndKey(path, k),
-			})
+	if !opts.extraKeysAllowed(path) {
+		for k := range maps.Keys(actual) {
+			if opts.ignored(path.child(k)) {
+				continue
+			}
+			_, exists := expected[k]
+			if !exists {
+				diffs = append(diffs, difference{
+					diff:   "unexpected key",
+					path:   path.child(k),
+					kind:   DiffUnexpected,
+					actual: actual[k],
+				})
+			}
 		}
 	}
 
-	for key, expectedValue := range expected {
-		// we know that this key is not present on actual
+	// keys are compared in order, so that a placeholder captures the same occurrence of a value every time
+	for _, key := range slices.Sorted(maps.Keys(expected)) {
+		expectedValue := expected[key]
+		// keys not present on actual are already reported
 		// so we can skip
-		if diffs.hasPath(pathAndKey(path, key)) {
+		actualValue, exists := actual[key]
+		if !exists || opts.ignored(path.child(key)) {
 			continue
 		}
 
-		actualValue := actual[key]
-
-		equal, diff := compareValues(pathAndKey(path, key), expectedValue, actualValue, opts)
+		equal, diff := compareValues(path.child(key), expectedValue, actualValue, opts)
 		if equal {
 			continue
 		}
@@ -125,13 +112,12 @@ func compareObjects(path string, expected, actual Obj, opts equalOptions) differ
 	return diffs
 }
 
-func pathAndKey(path, key string) string {
-	return fmt.Sprintf("%s.%s", path, key)
-}
-
 // String returns the JSON representation of the Obj as a string.
 // It fails if the marshaling fails.
 func (ob Obj) String(t T) string {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	data, err := json.Marshal(ob)
 	if err != nil {
 		t.Fatalf(fmt.Sprintf("error marshaling JSON object: %v", err))
@@ -152,6 +138,9 @@ func (ob Obj) MustString() string {
 // Bytes returns the JSON representation of the Obj as a byte slice.
 // It fails if the marshaling fails.
 func (ob Obj) Bytes(t T) []byte {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	data, err := json.Marshal(ob)
 	if err != nil {
 		t.Fatalf(fmt.Sprintf("error marshaling JSON object: %v", err))
@@ -171,23 +160,67 @@ func (ob Obj) MustBytes() []byte {
 }
 
 // Get retrieves a value from the Obj at the specified path.
-// The path is a JSONPath-like string that specifies the location of the value.
+// The path is a JSONPath string that specifies the location of the value.
+// Paths that can match several values, e.g. with a wildcard, must match exactly one; use GetAll otherwise.
 // If the path is invalid or the value cannot be retrieved, it calls t.Fatal
 func (o Obj) Get(t T, path string) any {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	val, err := o.GetE(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return val
+}
+
+// GetE retrieves a value from the Obj at the specified path like Get, but returns an error instead of failing a test.
+func (o Obj) GetE(path string) (any, error) {
 	normed, err := normalize(o)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("err normalizing arr: %v", err))
+		return nil, fmt.Errorf("err normalizing obj: %v", err)
 	}
 	val, err := getValue(path, normed)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("failed to get value at path '%s': %v", path, err))
+		return nil, fmt.Errorf("failed to get value at path '%s': %v", path, err)
 	}
+	return clone(val), nil
+}
 
-	return val
+// GetAll retrieves every value from the Obj matched by the specified path.
+// The path may use JSONPath wildcards ($.users[*].id), recursive descent ($..email),
+// slices ($.items[0:3]) and filters ($.items[?(@.status=="active")]).
+// If no values match, an empty Arr is returned. If the path is invalid, it calls t.Fatal
+func (o Obj) GetAll(t T, path string) Arr {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	vals, err := o.GetAllE(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return vals
+}
+
+// GetAllE retrieves every value from the Obj matched by the specified path like GetAll,
+// but returns an error instead of failing a test.
+func (o Obj) GetAllE(path string) (Arr, error) {
+	normed, err := normalize(o)
+	if err != nil {
+		return nil, fmt.Errorf("err normalizing obj: %v", err)
+	}
+	vals, err := getAll(path, normed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values at path '%s': %v", path, err)
+	}
+	return clone(vals).(Arr), nil
 }
 
 // GetString functions like Get but attempts to convert to string. Fails if the value at the path is not a string.
 func (o Obj) GetString(t T, path string) string {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := o.Get(t, path)
 	if str, ok := val.(string); ok {
 		return str
@@ -198,16 +231,56 @@ func (o Obj) GetString(t T, path string) string {
 
 // GetNumber functions like Get but attempts to convert to float64. Fails if the value at the path is not a number.
 func (o Obj) GetNumber(t T, path string) float64 {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := o.Get(t, path)
-	if num, ok := val.(float64); ok {
+	switch num := val.(type) {
+	case float64:
 		return num
+	case json.Number:
+		return floatValue(num)
 	}
 	t.Fatalf(fmt.Sprintf("expected number at path '%s', got %T", path, val))
 	return 0
 }
 
+// GetInt64 functions like Get but attempts to convert to int64. Fails if the value at the path is not an integer
+// or out of the range of an int64. Use WithUseNumber when parsing to keep the precision of integers beyond 2^53.
+func (o Obj) GetInt64(t T, path string) int64 {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	num := o.GetBigInt(t, path)
+	if num == nil {
+		return 0
+	}
+	if !num.IsInt64() {
+		t.Fatalf(fmt.Sprintf("expected int64 at path '%s', got %v which is out of range", path, num))
+		return 0
+	}
+	return num.Int64()
+}
+
+// GetBigInt functions like Get but attempts to convert to *big.Int. Fails if the value at the path is not an integer.
+// Use WithUseNumber when parsing to keep the precision of integers beyond 2^53.
+func (o Obj) GetBigInt(t T, path string) *big.Int {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	val := o.Get(t, path)
+	if num, ok := integerValue(val); ok {
+		return num
+	}
+	t.Fatalf(fmt.Sprintf("expected integer at path '%s', got %T (%v)", path, val, val))
+	return nil
+}
+
 // GetBool functions like Get but attempts to convert to bool. Fails if the value at the path is not a boolean.
 func (o Obj) GetBool(t T, path string) bool {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := o.Get(t, path)
 	if b, ok := val.(bool); ok {
 		return b
@@ -218,6 +291,9 @@ func (o Obj) GetBool(t T, path string) bool {
 
 // GetArray functions like Get but attempts to convert to Arr. Fails if the value at the path is not an array.
 func (o Obj) GetArray(t T, path string) Arr {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := o.Get(t, path)
 	if arr, ok := val.(Arr); ok {
 		return arr
@@ -228,6 +304,9 @@ func (o Obj) GetArray(t T, path string) Arr {
 
 // GetObject functions like Get but attempts to convert to Obj. Fails if the value at the path is not an object.
 func (o Obj) GetObject(t T, path string) Obj {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
 	val := o.Get(t, path)
 	if obj, ok := val.(Obj); ok {
 		return obj
@@ -237,7 +316,8 @@ func (o Obj) GetObject(t T, path string) Obj {
 }
 
 // Set sets a value at the specified path in the Obj.
-// The path is a JSONPath-like string that specifies the location of the value.
+// The path is a JSONPath string that specifies the location of the value.
+// Paths that can match several values, e.g. $.users[*].active, set every matched location.
 // If the path is invalid or the value cannot be set, it fails.
 // After setting the value, it normalizes the Arr to ensure it is valid JSON.
 // If normalization fails, it calls Fatalf on T.
@@ -245,13 +325,78 @@ func (o Obj) GetObject(t T, path string) Obj {
 // The value can be of any type, but it will be normalized to one of the supported JSON types:
 // bool, string, float64, Obj, or Arr.
 func (o Obj) Set(t T, path string, value any) {
-	if err := setByPath(o, path, value); err != nil {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.SetE(path, value); err != nil {
 		t.Fatalf(err.Error())
 	}
+}
+
+// SetE sets a value at the specified path in the Obj like Set, but returns an error instead of failing a test.
+func (o Obj) SetE(path string, value any) error {
+	if err := setByPath(o, path, value); err != nil {
+		return err
+	}
 
 	normalA, err := normalize(o)
 	if err != nil {
-		t.Fatalf(fmt.Sprintf("invalid json array: %v", err))
+		return fmt.Errorf("invalid json array: %v", err)
 	}
 	maps.Copy(o, normalA)
+	return nil
+}
+
+// Delete removes the value at the specified path from the Obj.
+// The path can address a key or an array item; paths matching several values delete all of them.
+// If the path is invalid or nothing is found at the path, it fails.
+func (o Obj) Delete(t T, path string) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.DeleteE(path); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// DeleteE removes the value at the specified path from the Obj like Delete, but returns an error instead of failing a test.
+func (o Obj) DeleteE(path string) error {
+	_, err := deleteByPath(o, path)
+	return err
+}
+
+// Append appends values to the array at the specified path in the Obj.
+// If nothing exists at a path without wildcards, a new array is created.
+// The values are normalized like in Set.
+func (o Obj) Append(t T, path string, values ...any) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.AppendE(path, values...); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// AppendE appends values to the array at the specified path in the Obj like Append, but returns an error instead of failing a test.
+func (o Obj) AppendE(path string, values ...any) error {
+	_, err := appendByPath(o, path, values...)
+	return err
+}
+
+// Insert inserts value into the array at the specified path in the Obj before the item at index.
+// An index equal to the length of the array appends the value.
+// The value is normalized like in Set.
+func (o Obj) Insert(t T, path string, index int, value any) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.InsertE(path, index, value); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// InsertE inserts value into the array at the specified path in the Obj like Insert, but returns an error instead of failing a test.
+func (o Obj) InsertE(path string, index int, value any) error {
+	_, err := insertByPath(o, path, index, value)
+	return err
 }
@@ -0,0 +1,92 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObj_Append(t *testing.T) {
+	testCases := []struct {
+		name   string
+		data   jman.Obj
+		path   string
+		values []any
+		want   jman.Obj
+	}{
+		{
+			name:   "ExistingArray",
+			data:   jman.Obj{"items": jman.Arr{"a"}},
+			path:   "$.items",
+			values: []any{"b", "c"},
+			want:   jman.Obj{"items": jman.Arr{"a", "b", "c"}},
+		},
+		{
+			name:   "MissingArray",
+			data:   jman.Obj{},
+			path:   "$.nested.items",
+			values: []any{1},
+			want:   jman.Obj{"nested": jman.Obj{"items": jman.Arr{1}}},
+		},
+		{
+			name:   "EveryMatchedArray",
+			data:   jman.Obj{"users": jman.Arr{jman.Obj{"roles": jman.Arr{}}, jman.Obj{"roles": jman.Arr{"admin"}}}},
+			path:   "$.users[*].roles",
+			values: []any{"viewer"},
+			want: jman.Obj{"users": jman.Arr{
+				jman.Obj{"roles": jman.Arr{"viewer"}},
+				jman.Obj{"roles": jman.Arr{"admin", "viewer"}},
+			}},
+		},
+		{
+			name:   "NormalizedValues",
+			data:   jman.Obj{"items": jman.Arr{}},
+			path:   "$.items",
+			values: []any{map[string]any{"id": 1}},
+			want:   jman.Obj{"items": jman.Arr{jman.Obj{"id": float64(1)}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data
+			data.Append(t, tc.path, tc.values...)
+			data.Equal(t, tc.want)
+		})
+	}
+}
+
+func TestObj_Append_NotAnArray(t *testing.T) {
+	data := jman.Obj{"key1": "value1"}
+	assertFatalf(t, "expected array at path '$.key1', got string", func(mt jman.T) {
+		data.Append(mt, "$.key1", "value2")
+	})
+}
+
+func TestObj_Insert(t *testing.T) {
+	data := jman.Obj{"items": jman.Arr{"a", "c"}}
+
+	data.Insert(t, "$.items", 1, "b")
+	data.Insert(t, "$.items", 0, "start")
+	data.Insert(t, "$.items", 4, "end")
+
+	assert.Equal(t, jman.Obj{"items": jman.Arr{"start", "a", "b", "c", "end"}}, data)
+}
+
+func TestObj_Insert_OutOfBounds(t *testing.T) {
+	data := jman.Obj{"items": jman.Arr{"a"}}
+	assertFatalf(t, "index 3 out of bounds for array of length 1", func(mt jman.T) {
+		data.Insert(mt, "$.items", 3, "b")
+	})
+}
+
+func TestArr_Append(t *testing.T) {
+	data := jman.Arr{"a"}
+
+	data.Append(t, "$", "b", jman.Arr{})
+	data.Append(t, "$.2", 3)
+	data.Insert(t, "$", 0, "start")
+
+	assert.Equal(t, jman.Arr{"start", "a", "b", jman.Arr{float64(3)}}, data)
+}
@@ -0,0 +1,107 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObj_Delete(t *testing.T) {
+	testCases := []struct {
+		name string
+		data jman.Obj
+		path string
+		want jman.Obj
+	}{
+		{
+			name: "Key",
+			data: jman.Obj{"key1": "value1", "key2": "value2"},
+			path: "$.key2",
+			want: jman.Obj{"key1": "value1"},
+		},
+		{
+			name: "NestedKey",
+			data: jman.Obj{"key1": jman.Obj{"a": 1, "b": 2}},
+			path: "$.key1.a",
+			want: jman.Obj{"key1": jman.Obj{"b": 2}},
+		},
+		{
+			name: "ArrayItem",
+			data: jman.Obj{"items": jman.Arr{"a", "b", "c"}},
+			path: "$.items.1",
+			want: jman.Obj{"items": jman.Arr{"a", "c"}},
+		},
+		{
+			name: "KeyInEveryItem",
+			data: jman.Obj{"users": jman.Arr{jman.Obj{"id": 1, "secret": "x"}, jman.Obj{"id": 2, "secret": "y"}}},
+			path: "$.users[*].secret",
+			want: jman.Obj{"users": jman.Arr{jman.Obj{"id": 1}, jman.Obj{"id": 2}}},
+		},
+		{
+			name: "FilteredItems",
+			data: jman.Obj{"items": jman.Arr{1, 5, 2, 7}},
+			path: "$.items[?(@ > 3)]",
+			want: jman.Obj{"items": jman.Arr{1, 2}},
+		},
+		{
+			name: "RepeatedUnionItems",
+			data: jman.Obj{"items": jman.Arr{1, 2, 3}},
+			path: "$.items[0,0,2]",
+			want: jman.Obj{"items": jman.Arr{2}},
+		},
+		{
+			name: "JSONPointer",
+			data: jman.Obj{"a/b": jman.Arr{"x", "y"}},
+			path: "/a~1b/0",
+			want: jman.Obj{"a/b": jman.Arr{"y"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data
+			data.Delete(t, tc.path)
+			data.Equal(t, tc.want)
+		})
+	}
+}
+
+func TestObj_Delete_PathNotFound(t *testing.T) {
+	data := jman.Obj{"key1": "value1"}
+	assertFatalf(t, "key 'key2' not found in object", func(mt jman.T) {
+		data.Delete(mt, "$.key2")
+	})
+}
+
+func TestObj_Delete_Root(t *testing.T) {
+	data := jman.Obj{"key1": "value1"}
+	assertFatalf(t, "cannot delete the root value", func(mt jman.T) {
+		data.Delete(mt, "$")
+	})
+}
+
+func TestArr_Delete(t *testing.T) {
+	data := jman.Arr{"a", jman.Obj{"b": 1, "c": 2}, "d"}
+
+	data.Delete(t, "$.1.c")
+	data.Delete(t, "$[0]")
+
+	assert.Equal(t, jman.Arr{jman.Obj{"b": float64(1)}, "d"}, data)
+}
+
+func TestObj_DeleteE_MatchedArrayAndDescendant(t *testing.T) {
+	data := jman.Obj{"a": jman.Arr{jman.Arr{1, 2}, 5}, "b": jman.Arr{jman.Obj{"c": jman.Arr{3, 4}}}}
+
+	assert.NoError(t, data.DeleteE("$..[0]"))
+
+	assert.Equal(t, jman.Obj{"a": jman.Arr{float64(5)}, "b": jman.Arr{}}, data)
+}
+
+func TestArr_Delete_RepeatedUnion(t *testing.T) {
+	data := jman.Arr{1, 2, 3}
+
+	data.Delete(t, "$[0,0]")
+
+	assert.Equal(t, jman.Arr{float64(2), float64(3)}, data)
+}
@@ -284,6 +284,7 @@ expected {"items":["item1","item2","item3"]}
 actual {"items":["item1","item2"]}
 
 $.items expected 3 items - got 2 items
+$.items.2 "item3" removed from index 2
 `, func(mt jman.T) {
 		expected.Equal(mt, actual)
 	})
@@ -302,6 +303,7 @@ expected {"items":["item1","item2"]}
 actual {"items":["item1","item2","item3"]}
 
 $.items expected 2 items - got 3 items
+$.items.2 "item3" inserted at index 2
 `, func(mt jman.T) {
 		expected.Equal(mt, actual)
 	})
@@ -375,8 +377,8 @@ $.1.baz.1 expected "quux" - actual "QUUX"
 $.1.foo expected "bar" - actual "BAR"
 $.2 expected "world" - actual "WORLD"
 $.3.0 expected 1 - actual <nil>
-$.3.2 expected false - actual 1
-$.3.3 expected <nil> - actual false
+$.3.2 1 inserted at index 2
+$.3.3 null removed from index 3
 `, func(mt jman.T) {
 		expected.Equal(mt, actual)
 	})
@@ -414,3 +416,36 @@ $.key2.nestedKey2 expected 42 - actual "notANumber"
 		expected.Equal(mt, actual)
 	})
 }
+
+func TestObj_Equal_IgnoreArrayOrder_JSONPointer(t *testing.T) {
+	expected := jman.Obj{
+		"com.example": jman.Obj{"items": jman.Arr{"item1", "item2"}},
+	}
+	actual := jman.Obj{
+		"com.example": jman.Obj{"items": jman.Arr{"item2", "item1"}},
+	}
+
+	expected.Equal(t, actual,
+		jman.WithIgnoreArrayOrder("/com.example/items"),
+	)
+}
+
+func TestObj_Equal_Unequal_AmbiguousKeysDisplayPath(t *testing.T) {
+	expected := jman.Obj{
+		"com.example": jman.Obj{"42": "a", "": "b", "it's": "c"},
+	}
+	actual := jman.Obj{
+		"com.example": jman.Obj{"42": "x", "": "y", "it's": "z"},
+	}
+
+	assertFatalf(t, `expected not equal to actual:
+expected {"com.example":{"":"b","42":"a","it's":"c"}}
+actual {"com.example":{"":"y","42":"x","it's":"z"}}
+
+$['com.example']['42'] expected "a" - actual "x"
+$['com.example'][''] expected "b" - actual "y"
+$['com.example']['it\'s'] expected "c" - actual "z"
+`, func(mt jman.T) {
+		expected.Equal(mt, actual)
+	})
+}
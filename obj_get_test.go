@@ -99,3 +99,212 @@ func TestObj_Get_IncorrectConversion(t *testing.T) {
 		_ = data.GetNumber(mt, "$.key1")
 	})
 }
+
+func TestObj_Get_JSONPath(t *testing.T) {
+	data := jman.Obj{
+		"users": jman.Arr{
+			jman.Obj{"id": 1, "status": "active", "email": "a@example.com"},
+			jman.Obj{"id": 2, "status": "inactive", "email": "b@example.com"},
+		},
+	}
+
+	testCases := []struct {
+		name string
+		path string
+		want any
+	}{
+		{
+			name: "BracketIndex",
+			path: "$.users[1].id",
+			want: float64(2),
+		},
+		{
+			name: "NegativeIndex",
+			path: "$.users[-1].id",
+			want: float64(2),
+		},
+		{
+			name: "BracketName",
+			path: "$['users'][0]['email']",
+			want: "a@example.com",
+		},
+		{
+			name: "FilterSingleMatch",
+			path: `$.users[?(@.status=="active")].email`,
+			want: "a@example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, data.Get(t, tc.path))
+		})
+	}
+}
+
+func TestObj_Get_MultipleMatches(t *testing.T) {
+	data := jman.Obj{"items": jman.Arr{1, 2}}
+	assertFatalf(t, "failed to get value at path '$.items[*]': path '$.items[*]' matched 2 values, use GetAll to retrieve all of them", func(mt jman.T) {
+		_ = data.Get(mt, "$.items[*]")
+	})
+}
+
+func TestObj_GetAll(t *testing.T) {
+	data := jman.Obj{
+		"users": jman.Arr{
+			jman.Obj{"id": 1, "status": "active", "email": "a@example.com"},
+			jman.Obj{"id": 2, "status": "inactive", "email": "b@example.com"},
+			jman.Obj{"id": 3, "status": "active", "profile": jman.Obj{"email": "c@example.com"}},
+		},
+		"total": 3,
+	}
+
+	testCases := []struct {
+		name string
+		path string
+		want jman.Arr
+	}{
+		{
+			name: "Wildcard",
+			path: "$.users[*].id",
+			want: jman.Arr{float64(1), float64(2), float64(3)},
+		},
+		{
+			name: "DotWildcard",
+			path: "$.users.*.status",
+			want: jman.Arr{"active", "inactive", "active"},
+		},
+		{
+			name: "RecursiveDescent",
+			path: "$..email",
+			want: jman.Arr{"a@example.com", "b@example.com", "c@example.com"},
+		},
+		{
+			name: "Slice",
+			path: "$.users[0:2].id",
+			want: jman.Arr{float64(1), float64(2)},
+		},
+		{
+			name: "SliceNegativeStep",
+			path: "$.users[::-1].id",
+			want: jman.Arr{float64(3), float64(2), float64(1)},
+		},
+		{
+			name: "Union",
+			path: "$.users[0,2].id",
+			want: jman.Arr{float64(1), float64(3)},
+		},
+		{
+			name: "FilterEquality",
+			path: `$.users[?(@.status=="active")].id`,
+			want: jman.Arr{float64(1), float64(3)},
+		},
+		{
+			name: "FilterComparisonWithRoot",
+			path: "$.users[?@.id < $.total && @.status != 'inactive'].id",
+			want: jman.Arr{float64(1)},
+		},
+		{
+			name: "FilterExistence",
+			path: "$.users[?(!@.profile)].id",
+			want: jman.Arr{float64(1), float64(2)},
+		},
+		{
+			name: "NoMatches",
+			path: "$.users[?(@.id > 10)]",
+			want: jman.Arr{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, data.GetAll(t, tc.path))
+		})
+	}
+}
+
+func TestObj_GetAll_InvalidPath(t *testing.T) {
+	data := jman.Obj{"items": jman.Arr{1, 2}}
+	assertFatalf(t, "failed to get values at path '$.items[?(@.id ==]': invalid path '$.items[?(@.id ==]': unexpected character ']' at position 17", func(mt jman.T) {
+		_ = data.GetAll(mt, "$.items[?(@.id ==]")
+	})
+}
+
+func TestArr_GetAll(t *testing.T) {
+	data := jman.Arr{
+		jman.Obj{"id": 1, "tags": jman.Arr{"a", "b"}},
+		jman.Obj{"id": 2, "tags": jman.Arr{"c"}},
+	}
+
+	assert.Equal(t, jman.Arr{"a", "b", "c"}, data.GetAll(t, "$[*].tags[*]"))
+	assert.Equal(t, float64(2), data.Get(t, "$[?(@.tags[0] == 'c')].id"))
+}
+
+func TestObj_Get_AmbiguousKeys(t *testing.T) {
+	data := jman.Obj{
+		"com.example.flag": true,
+		"":                 "empty",
+		"42":               jman.Obj{"a/b": "slash", "m~n": "tilde"},
+		"items":            jman.Arr{"first", "second"},
+	}
+
+	testCases := []struct {
+		name string
+		path string
+		want any
+	}{
+		{
+			name: "BracketDottedKey",
+			path: "$['com.example.flag']",
+			want: true,
+		},
+		{
+			name: "BracketEmptyKey",
+			path: "$['']",
+			want: "empty",
+		},
+		{
+			name: "DigitKey",
+			path: "$.42['a/b']",
+			want: "slash",
+		},
+		{
+			name: "PointerDottedKey",
+			path: "/com.example.flag",
+			want: true,
+		},
+		{
+			name: "PointerEmptyKey",
+			path: "/",
+			want: "empty",
+		},
+		{
+			name: "PointerEscapes",
+			path: "/42/a~1b",
+			want: "slash",
+		},
+		{
+			name: "PointerTilde",
+			path: "/42/m~0n",
+			want: "tilde",
+		},
+		{
+			name: "PointerIndex",
+			path: "/items/1",
+			want: "second",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, data.Get(t, tc.path))
+		})
+	}
+}
+
+func TestObj_Get_PointerInvalidEscape(t *testing.T) {
+	data := jman.Obj{"key1": "value1"}
+	assertFatalf(t, `failed to get value at path '/key~2': invalid JSON pointer '/key~2': invalid escape in "key~2"`, func(mt jman.T) {
+		_ = data.Get(mt, "/key~2")
+	})
+}
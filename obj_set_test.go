@@ -92,3 +92,78 @@ func TestObj_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestObj_Set_JSONPath(t *testing.T) {
+	testCases := []struct {
+		name  string
+		data  jman.Obj
+		path  string
+		value any
+		want  jman.Obj
+	}{
+		{
+			name:  "Wildcard",
+			data:  jman.Obj{"users": jman.Arr{jman.Obj{"id": 1}, jman.Obj{"id": 2}}},
+			path:  "$.users[*].active",
+			value: true,
+			want: jman.Obj{"users": jman.Arr{
+				jman.Obj{"id": 1, "active": true},
+				jman.Obj{"id": 2, "active": true},
+			}},
+		},
+		{
+			name:  "Filter",
+			data:  jman.Obj{"users": jman.Arr{jman.Obj{"id": 1}, jman.Obj{"id": 2}}},
+			path:  "$.users[?(@.id == 2)].id",
+			value: 3,
+			want: jman.Obj{"users": jman.Arr{
+				jman.Obj{"id": 1},
+				jman.Obj{"id": 3},
+			}},
+		},
+		{
+			name:  "GrowNestedArray",
+			data:  jman.Obj{"items": jman.Arr{"a"}},
+			path:  "$.items[2]",
+			value: "c",
+			want:  jman.Obj{"items": jman.Arr{"a", nil, "c"}},
+		},
+		{
+			name:  "CreateNestedArray",
+			data:  jman.Obj{},
+			path:  "$.items.0.name",
+			value: "a",
+			want:  jman.Obj{"items": jman.Arr{jman.Obj{"name": "a"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data
+			data.Set(t, tc.path, tc.value)
+			data.Equal(t, tc.want)
+		})
+	}
+}
+
+func TestObj_Set_NoMatches(t *testing.T) {
+	data := jman.Obj{"users": jman.Arr{}}
+	assertFatalf(t, "path '$.users[*].active' matched no values", func(mt jman.T) {
+		data.Set(mt, "$.users[*].active", true)
+	})
+}
+
+func TestObj_Set_AmbiguousKeys(t *testing.T) {
+	data := jman.Obj{"items": jman.Arr{"a"}}
+	data.Set(t, "$['com.example.flag']", true)
+	data.Set(t, "/a~1b/c", 1)
+	data.Set(t, "$['123']", "digits")
+	data.Set(t, "/items/0", "b")
+
+	assert.Equal(t, jman.Obj{
+		"com.example.flag": true,
+		"a/b":              jman.Obj{"c": float64(1)},
+		"123":              "digits",
+		"items":            jman.Arr{"b"},
+	}, data)
+}
@@ -1,28 +1,326 @@
 package jman
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
 const base = "$"
 
 type optsFunc func(o *equalOptions)
 
 type equalOptions struct {
 	matchers         Matchers
-	ignoreArrayOrder []string
+	ignoreArrayOrder pathPatterns
+	allowExtraKeys   bool
+	allowExtraKeysAt pathPatterns
+	ignorePaths      pathPatterns
+	ignoreKeys       []string
+	arrayKeys        []arrayKey
+	floatTolerances  []floatTolerance
+	useNumber        bool
+	paramMatchers    map[string]ParamMatcherFunc
+	params           *paramMatcherCache
+	captures         *captureState
+}
+
+func newEqualOptions(optFuncs []optsFunc) equalOptions {
+	opts := equalOptions{params: &paramMatcherCache{}}
+	for _, o := range optFuncs {
+		o(&opts)
+	}
+	// without matchers created with Capture there is nothing to capture, so trials don't need to fork
+	if slices.ContainsFunc(opts.matchers, func(m Matcher) bool { return len(m.captures) > 0 }) {
+		opts.captures = &captureState{}
+	}
+	return opts
+}
+
+// findMatcher returns the matcher for a placeholder in expected, either a matcher given with WithMatchers
+// or a parameterized matcher for a placeholder of the form $name(arg).
+func (o equalOptions) findMatcher(placeholder string) (Matcher, bool) {
+	if m, found := o.matchers.FindByPlaceholder(placeholder); found {
+		return m, true
+	}
+	name, arg, ok := parseParamPlaceholder(placeholder)
+	if !ok {
+		return Matcher{}, false
+	}
+	fn, ok := o.paramMatchers[name]
+	if !ok {
+		if fn, ok = registeredParamMatcher(name); !ok {
+			return Matcher{}, false
+		}
+	}
+	if o.params == nil {
+		o.params = &paramMatcherCache{}
+	}
+	return o.params.matcher(placeholder, arg, fn), true
+}
+
+// trial returns the options for comparing values only to find out whether they are equal,
+// which discard the values captured while comparing them.
+func (o equalOptions) trial() equalOptions {
+	o.captures = o.captures.fork()
+	return o
+}
+
+// numbers returns how numbers are represented in the compared values.
+func (o equalOptions) numbers() numberMode {
+	if o.useNumber {
+		return exactNumbers
+	}
+	return floatNumbers
+}
+
+// arrayKey pairs the items of the arrays at a path by the values of their key fields.
+type arrayKey struct {
+	pattern pathPattern
+	fields  []string
+}
+
+// floatTolerance allows numbers to differ by epsilon, or by epsilon times the expected number if it is relative.
+// It applies to every number if pattern is nil, otherwise to the numbers at the pattern and nested in it.
+type floatTolerance struct {
+	pattern  *pathPattern
+	epsilon  float64
+	relative bool
+}
+
+// applies reports whether the tolerance applies to the number at the location.
+func (f floatTolerance) applies(l *location) bool {
+	if f.pattern == nil {
+		return true
+	}
+	if f.pattern.err != nil {
+		return false
+	}
+	for ; l != nil; l = l.parent {
+		if f.pattern.path.matches(l) {
+			return true
+		}
+	}
+	return f.pattern.path.matches(nil)
+}
+
+// appliesWithin reports whether the tolerance can apply to a number at the location or nested in it.
+func (f floatTolerance) appliesWithin(l *location) bool {
+	return f.applies(l) || f.pattern.err == nil && f.pattern.path.matchesWithin(l)
 }
 
 func (o equalOptions) valid() error {
-	if len(o.ignoreArrayOrder) == 0 {
-		return nil
+	if err := o.ignoreArrayOrder.valid(); err != nil {
+		return err
+	}
+	if err := o.allowExtraKeysAt.valid(); err != nil {
+		return err
+	}
+	if err := o.ignorePaths.valid(); err != nil {
+		return err
+	}
+	if slices.Contains(o.ignoreKeys, "") {
+		return errors.New("ignored key cannot be empty")
+	}
+	for _, k := range o.arrayKeys {
+		if k.pattern.err != nil {
+			return k.pattern.err
+		}
+		if len(k.fields) == 0 || slices.Contains(k.fields, "") {
+			return fmt.Errorf("array key for path '%s' must name at least one non-empty field", k.pattern.path)
+		}
 	}
+	for name := range o.paramMatchers {
+		if !validParamName(name) {
+			return fmt.Errorf("invalid param matcher name %q", name)
+		}
+	}
+	for _, f := range o.floatTolerances {
+		if f.pattern != nil && f.pattern.err != nil {
+			return f.pattern.err
+		}
+		if math.IsNaN(f.epsilon) || math.IsInf(f.epsilon, 0) || f.epsilon < 0 {
+			return fmt.Errorf("float tolerance must be a non-negative number - got %v", f.epsilon)
+		}
+	}
+	return nil
+}
 
-	for _, key := range o.ignoreArrayOrder {
-		_, err := getPathParts(key)
-		if err != nil {
-			return err
+// exact reports whether the value v at the location can only be equal to values with the same canonical form,
+// i.e. it contains no placeholders and no option relaxes the comparison of it or of anything nested in it.
+func (o equalOptions) exact(l *location, v any) bool {
+	if o.extraKeysAllowed(l) && hasObject(v) {
+		return false
+	}
+	for _, ps := range []pathPatterns{o.ignorePaths, o.allowExtraKeysAt, o.ignoreArrayOrder} {
+		if ps.matchWithin(l) {
+			return false
+		}
+	}
+	for _, k := range o.arrayKeys {
+		if k.pattern.err == nil && k.pattern.path.matchesWithin(l) {
+			return false
+		}
+	}
+	for _, f := range o.floatTolerances {
+		if f.appliesWithin(l) && hasNumber(v) {
+			return false
+		}
+	}
+	return o.plain(v)
+}
+
+func hasObject(v any) bool {
+	switch typed := v.(type) {
+	case Obj:
+		return true
+	case Arr:
+		return slices.ContainsFunc(typed, hasObject)
+	}
+	return false
+}
+
+func hasNumber(v any) bool {
+	switch typed := v.(type) {
+	case float64, json.Number:
+		return true
+	case Obj:
+		for _, val := range typed {
+			if hasNumber(val) {
+				return true
+			}
+		}
+	case Arr:
+		return slices.ContainsFunc(typed, hasNumber)
+	}
+	return false
+}
+
+// plain reports whether v only consists of normalized JSON values without placeholders.
+func (o equalOptions) plain(v any) bool {
+	switch typed := v.(type) {
+	case nil, bool, float64, json.Number:
+		return true
+	case string:
+		_, found := o.findMatcher(typed)
+		return !found
+	case Obj:
+		for _, val := range typed {
+			if !o.plain(val) {
+				return false
+			}
+		}
+		return true
+	case Arr:
+		for _, val := range typed {
+			if !o.plain(val) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// keyFields returns the key fields of the array at the location, if its items are paired by key.
+func (o equalOptions) keyFields(l *location) ([]string, bool) {
+	for _, k := range o.arrayKeys {
+		if k.pattern.err == nil && k.pattern.path.matches(l) {
+			return k.fields, true
+		}
+	}
+	return nil, false
+}
+
+// tolerance returns how much an actual number may differ from the expected number at the location,
+// the largest of the tolerances that apply to it, and false if none do.
+func (o equalOptions) tolerance(l *location, expected float64) (float64, bool) {
+	var (
+		tolerance float64
+		found     bool
+	)
+	for _, f := range o.floatTolerances {
+		if !f.applies(l) {
+			continue
+		}
+		epsilon := f.epsilon
+		if f.relative {
+			epsilon *= math.Abs(expected)
+		}
+		tolerance = max(tolerance, epsilon)
+		found = true
+	}
+	return tolerance, found
+}
+
+// ignored reports whether the object key at the location is left out of the comparison.
+func (o equalOptions) ignored(l *location) bool {
+	return slices.Contains(o.ignoreKeys, l.key) || o.ignorePaths.match(l)
+}
+
+// extraKeysAllowed reports whether keys missing from expected are allowed in the actual object at the location.
+func (o equalOptions) extraKeysAllowed(l *location) bool {
+	if o.allowExtraKeys {
+		return true
+	}
+	for ; l != nil; l = l.parent {
+		if o.allowExtraKeysAt.match(l) {
+			return true
+		}
+	}
+	return o.allowExtraKeysAt.match(nil)
+}
+
+// pathPattern is a path given in the options, parsed when the option is created.
+type pathPattern struct {
+	path jsonPath
+	err  error
+}
+
+type pathPatterns []pathPattern
+
+func newPathPatterns(paths []string) pathPatterns {
+	patterns := make(pathPatterns, len(paths))
+	for i, p := range paths {
+		patterns[i].path, patterns[i].err = parsePath(p)
+		if patterns[i].err == nil {
+			patterns[i].err = patterns[i].path.positional()
+		}
+	}
+	return patterns
+}
+
+func (ps pathPatterns) valid() error {
+	for _, p := range ps {
+		if p.err != nil {
+			return p.err
 		}
 	}
 	return nil
 }
 
+// matchWithin reports whether any of the patterns can select the location or a location nested in it.
+func (ps pathPatterns) matchWithin(l *location) bool {
+	for _, p := range ps {
+		if p.err == nil && p.path.matchesWithin(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether any of the patterns selects the location.
+func (ps pathPatterns) match(l *location) bool {
+	for _, p := range ps {
+		if p.err == nil && p.path.matches(l) {
+			return true
+		}
+	}
+	return false
+}
+
 // WithMatchers allows you to add matchers to the comparison options.
 func WithMatchers(matchers ...Matcher) optsFunc {
 	return func(o *equalOptions) {
@@ -42,9 +340,107 @@ func WithDefaultMatchers(matchers Matchers) optsFunc {
 
 // WithIgnoreArrayOrder allows you to specify keys for which the order of array elements should be ignored during comparison.
 // each key should be a valid JSON path, and the order of elements in arrays at those paths will not be considered during comparison.
-// the path must start with $.  For ignoring order of the base array, use "$" as the key.
+// the path must start with $ or be a JSON pointer.  For ignoring order of the base array, use "$" as the key.
 func WithIgnoreArrayOrder(keys ...string) optsFunc {
 	return func(o *equalOptions) {
-		o.ignoreArrayOrder = append(o.ignoreArrayOrder, keys...)
+		o.ignoreArrayOrder = append(o.ignoreArrayOrder, newPathPatterns(keys)...)
+	}
+}
+
+// WithAllowExtraKeys allows keys in actual objects that are not in expected, so expected acts as a subset
+// of actual. Every key in expected still has to be in actual, and arrays still have to be of the same length.
+func WithAllowExtraKeys() optsFunc {
+	return func(o *equalOptions) {
+		o.allowExtraKeys = true
+	}
+}
+
+// WithAllowExtraKeysAt allows extra keys like WithAllowExtraKeys, but only in the objects at the given paths
+// and the objects nested in them. Each path must start with $ or be a JSON pointer.
+func WithAllowExtraKeysAt(paths ...string) optsFunc {
+	return func(o *equalOptions) {
+		o.allowExtraKeysAt = append(o.allowExtraKeysAt, newPathPatterns(paths)...)
+	}
+}
+
+// WithIgnorePaths leaves the object keys at the given paths out of the comparison, whether they are
+// in expected, actual or both. Paths can use wildcards to ignore a key in every item of an array,
+// e.g. "$.items.*.updatedAt". Each path must start with $ or be a JSON pointer.
+func WithIgnorePaths(paths ...string) optsFunc {
+	return func(o *equalOptions) {
+		o.ignorePaths = append(o.ignorePaths, newPathPatterns(paths)...)
+	}
+}
+
+// WithIgnoreKeys leaves object keys with the given names out of the comparison at any depth,
+// e.g. WithIgnoreKeys("createdAt", "etag").
+func WithIgnoreKeys(names ...string) optsFunc {
+	return func(o *equalOptions) {
+		o.ignoreKeys = append(o.ignoreKeys, names...)
+	}
+}
+
+// WithArrayKey pairs the items of the array at path by the values of the given key fields rather than by index,
+// e.g. WithArrayKey("$.users", "id"), so that differences are reported per field of the paired items,
+// like $.users[id=42].email, and items are reported missing or unexpected by their key.
+// Several fields form a composite key. It takes precedence over WithIgnoreArrayOrder for the same array.
+// The path must start with $ or be a JSON pointer.
+func WithArrayKey(path string, fields ...string) optsFunc {
+	return func(o *equalOptions) {
+		o.arrayKeys = append(o.arrayKeys, arrayKey{pattern: newPathPatterns([]string{path})[0], fields: fields})
+	}
+}
+
+// WithFloatTolerance allows actual numbers to differ from expected numbers by up to epsilon,
+// e.g. WithFloatTolerance(1e-9) so that 0.1+0.2 equals 0.3.
+func WithFloatTolerance(epsilon float64) optsFunc {
+	return func(o *equalOptions) {
+		o.floatTolerances = append(o.floatTolerances, floatTolerance{epsilon: epsilon})
+	}
+}
+
+// WithFloatToleranceAt allows numbers to differ like WithFloatTolerance, but only the numbers at the given path
+// and the numbers nested in it, e.g. WithFloatToleranceAt("$.items.*.price", 0.005).
+// The path must start with $ or be a JSON pointer.
+func WithFloatToleranceAt(path string, epsilon float64) optsFunc {
+	return func(o *equalOptions) {
+		o.floatTolerances = append(o.floatTolerances, floatTolerance{pattern: &newPathPatterns([]string{path})[0], epsilon: epsilon})
+	}
+}
+
+// WithRelativeFloatTolerance allows actual numbers to differ from expected numbers by up to ratio times
+// the expected number, e.g. WithRelativeFloatTolerance(0.01) allows 1% either way.
+func WithRelativeFloatTolerance(ratio float64) optsFunc {
+	return func(o *equalOptions) {
+		o.floatTolerances = append(o.floatTolerances, floatTolerance{epsilon: ratio, relative: true})
+	}
+}
+
+// WithRelativeFloatToleranceAt allows numbers to differ like WithRelativeFloatTolerance, but only the numbers
+// at the given path and the numbers nested in it. The path must start with $ or be a JSON pointer.
+func WithRelativeFloatToleranceAt(path string, ratio float64) optsFunc {
+	return func(o *equalOptions) {
+		o.floatTolerances = append(o.floatTolerances, floatTolerance{pattern: &newPathPatterns([]string{path})[0], epsilon: ratio, relative: true})
+	}
+}
+
+// WithUseNumber keeps numbers as json.Number rather than float64, so that integers beyond 2^53, like 64-bit IDs,
+// keep their precision and numbers are compared exactly, e.g. 9007199254740993 differs from 9007199254740992.
+// Numbers with the same value but written differently, like 1.0 and 1, are still equal.
+// It can also be passed to New, NewE, NewFromFile and NewFromFileE to keep the numbers of the parsed Obj or Arr.
+func WithUseNumber() optsFunc {
+	return func(o *equalOptions) {
+		o.useNumber = true
+	}
+}
+
+// WithParamMatcher adds a parameterized matcher for placeholders of the form $name(arg) to this comparison only,
+// taking precedence over one registered with RegisterParamMatcher under the same name.
+func WithParamMatcher(name string, fn ParamMatcherFunc) optsFunc {
+	return func(o *equalOptions) {
+		if o.paramMatchers == nil {
+			o.paramMatchers = map[string]ParamMatcherFunc{}
+		}
+		o.paramMatchers[name] = fn
 	}
 }
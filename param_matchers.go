@@ -0,0 +1,186 @@
+package jman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ParamMatcherFunc creates the MatchFunc of a parameterized placeholder from its argument, the text between
+// the parentheses, e.g. "3" for "$len(3)". It returns an error if the argument is invalid, which is reported
+// as the reason the value doesn't match.
+type ParamMatcherFunc func(arg string) (MatchFunc, error)
+
+// paramMatchers is the registry of parameterized matchers shared by all comparisons.
+var paramMatchers = struct {
+	sync.RWMutex
+	funcs map[string]ParamMatcherFunc
+}{
+	funcs: map[string]ParamMatcherFunc{
+		"regex": regexParam,
+		"len":   lenParam,
+		"gt":    compareParam("greater than", func(c int) bool { return c > 0 }),
+		"gte":   compareParam("greater than or equal to", func(c int) bool { return c >= 0 }),
+		"lt":    compareParam("less than", func(c int) bool { return c < 0 }),
+		"lte":   compareParam("less than or equal to", func(c int) bool { return c <= 0 }),
+		"oneOf": oneOfParam,
+	},
+}
+
+// RegisterParamMatcher adds a parameterized matcher for placeholders of the form $name(arg) in every comparison,
+// e.g. RegisterParamMatcher("prefix", ...) for "$prefix(ord_)". It replaces a matcher registered with the same
+// name, including the built-in ones. It is safe to call concurrently, but usually belongs in an init function
+// or TestMain. It panics if the name is not a valid identifier.
+func RegisterParamMatcher(name string, fn ParamMatcherFunc) {
+	if !validParamName(name) {
+		panic(fmt.Sprintf("jman: invalid param matcher name %q", name))
+	}
+	paramMatchers.Lock()
+	defer paramMatchers.Unlock()
+	paramMatchers.funcs[name] = fn
+}
+
+func registeredParamMatcher(name string) (ParamMatcherFunc, bool) {
+	paramMatchers.RLock()
+	defer paramMatchers.RUnlock()
+	fn, ok := paramMatchers.funcs[name]
+	return fn, ok
+}
+
+// validParamName reports whether name is an identifier of letters, digits and underscores not starting with a digit.
+func validParamName(name string) bool {
+	if name == "" || isDigit(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !isDigit(c) && c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseParamPlaceholder splits a placeholder of the form $name(arg) into its name and argument.
+// The argument is everything up to the last parenthesis, so it can contain parentheses itself.
+func parseParamPlaceholder(s string) (string, string, bool) {
+	if len(s) < 4 || s[0] != '$' || s[len(s)-1] != ')' {
+		return "", "", false
+	}
+	name, arg, found := strings.Cut(s[1:len(s)-1], "(")
+	if !found || !validParamName(name) {
+		return "", "", false
+	}
+	return name, arg, true
+}
+
+// paramMatcherCache keeps the matchers created for the parameterized placeholders of a comparison,
+// so that an argument like a regular expression is only parsed once.
+type paramMatcherCache struct {
+	matchers map[string]Matcher
+}
+
+func (c *paramMatcherCache) matcher(placeholder, arg string, fn ParamMatcherFunc) Matcher {
+	if m, ok := c.matchers[placeholder]; ok {
+		return m
+	}
+	matchFunc, err := fn(arg)
+	if err != nil {
+		matchFunc = func(any) error {
+			return fmt.Errorf("invalid placeholder: %w", err)
+		}
+	}
+	m := newMatcher(placeholder, matchFunc)
+	if c.matchers == nil {
+		c.matchers = map[string]Matcher{}
+	}
+	c.matchers[placeholder] = m
+	return m
+}
+
+func regexParam(arg string) (MatchFunc, error) {
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return nil, err
+	}
+	return regexMatchFunc(re), nil
+}
+
+func lenParam(arg string) (MatchFunc, error) {
+	want, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || want < 0 {
+		return nil, fmt.Errorf("length %q is not a non-negative integer", arg)
+	}
+	return func(v any) error {
+		got, err := jsonLen(v)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("length %d instead of %d", got, want)
+		}
+		return nil
+	}, nil
+}
+
+// compareParam creates parameterized matchers that compare numbers with their argument,
+// accepting the numbers for which ok holds of their comparison with the argument.
+func compareParam(relation string, ok func(c int) bool) ParamMatcherFunc {
+	return func(arg string) (MatchFunc, error) {
+		bound, valid := new(big.Rat).SetString(strings.TrimSpace(arg))
+		if !valid {
+			return nil, fmt.Errorf("%q is not a number", arg)
+		}
+		return func(v any) error {
+			n := exactValue(v)
+			if n == nil {
+				return fmt.Errorf("%s instead of number", jsonType(v))
+			}
+			if !ok(n.Cmp(bound)) {
+				return fmt.Errorf("not %s %s", relation, strings.TrimSpace(arg))
+			}
+			return nil
+		}, nil
+	}
+}
+
+// oneOfParam accepts any of a comma-separated list of values. Each value is JSON, like 1, true or "a,b",
+// or otherwise a string, so $oneOf(active,pending) accepts the strings "active" and "pending".
+func oneOfParam(arg string) (MatchFunc, error) {
+	var options []any
+	if err := json.Unmarshal([]byte("["+arg+"]"), &options); err != nil {
+		options = nil
+		for _, item := range strings.Split(arg, ",") {
+			item = strings.TrimSpace(item)
+			var option any
+			if err := json.Unmarshal([]byte(item), &option); err != nil {
+				option = item
+			}
+			options = append(options, option)
+		}
+	}
+	for i, option := range options {
+		options[i] = convert(option)
+	}
+	return func(v any) error {
+		for _, option := range options {
+			if valuesEqual(option, v) {
+				return nil
+			}
+		}
+		return fmt.Errorf("not one of %s", arg)
+	}, nil
+}
+
+// valuesEqual reports whether two scalar values are equal, comparing numbers by value.
+func valuesEqual(a, b any) bool {
+	if x, y := exactValue(a), exactValue(b); x != nil && y != nil {
+		return x.Cmp(y) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,114 @@
+package jman_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_ParamPlaceholders_FromJSON(t *testing.T) {
+	expected := `{
+		"id": "$regex(^ord_[0-9]+$)",
+		"items": "$len(2)",
+		"total": "$gt(10)",
+		"status": "$oneOf(paid,shipped)"
+	}`
+	actual := `{"id": "ord_123", "items": [{"sku": "a"}, {"sku": "b"}], "total": 10.5, "status": "shipped"}`
+
+	jman.Equal(t, expected, actual)
+}
+
+func TestDiff_ParamPlaceholders(t *testing.T) {
+	tests := map[string]struct {
+		placeholder string
+		actual      any
+		reason      string
+	}{
+		"regex":              {placeholder: "$regex(^(ord|inv)_[0-9]+$)", actual: "ord_1"},
+		"regex mismatch":     {placeholder: "$regex(^ord_[0-9]+$)", actual: "inv_1", reason: `does not match regular expression "^ord_[0-9]+$"`},
+		"regex not a string": {placeholder: "$regex(.*)", actual: 1, reason: "number instead of string"},
+		"len string":         {placeholder: "$len(3)", actual: "äbc"},
+		"len object":         {placeholder: "$len(1)", actual: jman.Obj{"a": 1}},
+		"len mismatch":       {placeholder: "$len(3)", actual: jman.Arr{1, 2}, reason: "length 2 instead of 3"},
+		"len of a number":    {placeholder: "$len(3)", actual: 3, reason: "number has no length"},
+		"gt":                 {placeholder: "$gt(10)", actual: 10.5},
+		"gt equal":           {placeholder: "$gt(10)", actual: 10, reason: "not greater than 10"},
+		"gte":                {placeholder: "$gte(10)", actual: 10},
+		"lt":                 {placeholder: "$lt(-1.5)", actual: -2},
+		"lte mismatch":       {placeholder: "$lte(0)", actual: 0.1, reason: "not less than or equal to 0"},
+		"gt not a number":    {placeholder: "$gt(1)", actual: "2", reason: "string instead of number"},
+		"oneOf strings":      {placeholder: "$oneOf(paid, shipped)", actual: "shipped"},
+		"oneOf numbers":      {placeholder: "$oneOf(1,2,3)", actual: 2},
+		"oneOf json":         {placeholder: `$oneOf("a,b",null)`, actual: nil},
+		"oneOf mismatch":     {placeholder: "$oneOf(paid,shipped)", actual: "cancelled", reason: "not one of paid,shipped"},
+		"invalid argument":   {placeholder: "$len(three)", actual: "abc", reason: `invalid placeholder: length "three" is not a non-negative integer`},
+		"invalid regex":      {placeholder: "$regex(()", actual: "abc", reason: "invalid placeholder: error parsing regexp: missing closing ): `(`"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diffs := jman.Diff(t, jman.Obj{"v": tt.placeholder}, jman.Obj{"v": tt.actual})
+
+			if tt.reason == "" {
+				assert.Empty(t, diffs)
+				return
+			}
+			assert.Len(t, diffs, 1)
+			assert.Equal(t, jman.DiffMatcherFailed, diffs[0].Kind)
+			assert.True(t, strings.HasSuffix(diffs[0].Message, ": "+tt.reason), diffs[0].Message)
+		})
+	}
+}
+
+func TestDiff_ParamPlaceholders_UnknownName(t *testing.T) {
+	diffs := jman.Diff(t, jman.Obj{"v": "$unknown(1)"}, jman.Obj{"v": "x"})
+
+	assert.Equal(t, []string{`$.v expected "$unknown(1)" - actual "x"`}, diffStrings(diffs))
+}
+
+func TestRegisterParamMatcher(t *testing.T) {
+	jman.RegisterParamMatcher("prefix", func(arg string) (jman.MatchFunc, error) {
+		return func(v any) error {
+			if str, ok := v.(string); ok && strings.HasPrefix(str, arg) {
+				return nil
+			}
+			return fmt.Errorf("does not start with %q", arg)
+		}, nil
+	})
+
+	jman.Equal(t, jman.Arr{"$prefix(ord_)", "$prefix(inv_)"}, jman.Arr{"ord_1", "inv_2"})
+
+	suffix := jman.WithParamMatcher("prefix", func(arg string) (jman.MatchFunc, error) {
+		return func(v any) error {
+			if str, ok := v.(string); ok && strings.HasSuffix(str, arg) {
+				return nil
+			}
+			return fmt.Errorf("does not end with %q", arg)
+		}, nil
+	})
+	diffs := jman.Diff(t, jman.Arr{"$prefix(_1)"}, jman.Arr{"ord_1"}, suffix)
+	assert.Empty(t, diffs)
+
+	assert.PanicsWithValue(t, `jman: invalid param matcher name "1st"`, func() {
+		jman.RegisterParamMatcher("1st", nil)
+	})
+}
+
+func TestEqual_ParamPlaceholders_IgnoreOrder(t *testing.T) {
+	expected := jman.Arr{
+		jman.Obj{"id": "$regex(^a)", "n": "$gt(5)"},
+		jman.Obj{"id": "$regex(^b)", "n": "$lt(5)"},
+	}
+	actual := jman.Arr{jman.Obj{"id": "b1", "n": 1}, jman.Obj{"id": "a1", "n": 9}}
+
+	jman.Equal(t, expected, actual, jman.WithIgnoreArrayOrder("$"))
+}
+
+func TestCompare_InvalidParamMatcherName(t *testing.T) {
+	_, err := jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithParamMatcher("has space", nil))
+
+	assert.EqualError(t, err, `invalid options: invalid param matcher name "has space"`)
+}
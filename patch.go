@@ -0,0 +1,343 @@
+package jman
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ApplyPatch applies an RFC 6902 JSON Patch to the Obj in place.
+// The patch is an array of operations (add, remove, replace, move, copy and test) whose paths are
+// JSON pointers, although any path accepted by Get can be used. The patch is applied atomically:
+// if any operation fails, including a failed test operation, the Obj is left unchanged and it fails.
+func (o Obj) ApplyPatch(t T, patch Arr) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := o.ApplyPatchE(patch); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// ApplyPatchE applies an RFC 6902 JSON Patch to the Obj in place like ApplyPatch, but returns an error instead of failing a test.
+func (o Obj) ApplyPatchE(patch Arr) error {
+	normed, err := normalize(o)
+	if err != nil {
+		return fmt.Errorf("%w %T: %v", ErrNormalize, o, err)
+	}
+	patched, err := applyPatch(clone(normed), patch)
+	if err != nil {
+		return err
+	}
+	result, ok := patched.(Obj)
+	if !ok {
+		return fmt.Errorf("patch replaced the object with %T", patched)
+	}
+	clear(o)
+	maps.Copy(o, result)
+	return nil
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch to the Arr in place.
+// The patch is an array of operations (add, remove, replace, move, copy and test) whose paths are
+// JSON pointers, although any path accepted by Get can be used. The patch is applied atomically:
+// if any operation fails, including a failed test operation, the Arr is left unchanged and it fails.
+func (a *Arr) ApplyPatch(t T, patch Arr) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	if err := a.ApplyPatchE(patch); err != nil {
+		t.Fatalf(err.Error())
+	}
+}
+
+// ApplyPatchE applies an RFC 6902 JSON Patch to the Arr in place like ApplyPatch, but returns an error instead of failing a test.
+func (a *Arr) ApplyPatchE(patch Arr) error {
+	normed, err := normalize(*a)
+	if err != nil {
+		return fmt.Errorf("%w %T: %v", ErrNormalize, *a, err)
+	}
+	patched, err := applyPatch(clone(normed), patch)
+	if err != nil {
+		return err
+	}
+	result, ok := patched.(Arr)
+	if !ok {
+		return fmt.Errorf("patch replaced the array with %T", patched)
+	}
+	*a = result
+	return nil
+}
+
+// Patch derives an RFC 6902 JSON Patch that transforms from into to. Both values can be
+// anything accepted by Equal. Objects are diffed key by key and arrays index by index,
+// the same way Equal compares them, producing add, remove and replace operations.
+func Patch(t T, from, to any) Arr {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	patch, err := PatchE(from, to)
+	if err != nil {
+		t.Fatalf(err.Error())
+		return nil
+	}
+	return patch
+}
+
+// PatchE derives an RFC 6902 JSON Patch that transforms from into to like Patch,
+// but returns an error instead of failing a test.
+func PatchE(from, to any) (Arr, error) {
+	fromVal, _, err := normalizeComparable(from, equalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	toVal, _, err := normalizeComparable(to, equalOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// values of different types, e.g. an object and an array, are replaced as a whole
+	return diffPatch(nil, fromVal, toVal, Arr{}), nil
+}
+
+func diffPatch(path *location, from, to any, ops Arr) Arr {
+	switch fromTyped := from.(type) {
+	case Obj:
+		toTyped, ok := to.(Obj)
+		if !ok {
+			break
+		}
+		for _, k := range slices.Sorted(maps.Keys(fromTyped)) {
+			if _, exists := toTyped[k]; !exists {
+				ops = append(ops, Obj{"op": "remove", "path": path.child(k).pointer()})
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(toTyped)) {
+			if _, exists := fromTyped[k]; !exists {
+				ops = append(ops, patchOp("add", path.child(k), toTyped[k]))
+				continue
+			}
+			ops = diffPatch(path.child(k), fromTyped[k], toTyped[k], ops)
+		}
+		return ops
+	case Arr:
+		toTyped, ok := to.(Arr)
+		if !ok {
+			break
+		}
+		for i := range min(len(fromTyped), len(toTyped)) {
+			ops = diffPatch(path.item(i), fromTyped[i], toTyped[i], ops)
+		}
+		// removing the last item first keeps the remaining indices valid
+		for i := len(fromTyped) - 1; i >= len(toTyped); i-- {
+			ops = append(ops, Obj{"op": "remove", "path": path.item(i).pointer()})
+		}
+		for i := len(fromTyped); i < len(toTyped); i++ {
+			ops = append(ops, patchOp("add", path.item(i), toTyped[i]))
+		}
+		return ops
+	}
+
+	if equal, _ := compareValues(path, to, from, equalOptions{}); !equal {
+		ops = append(ops, patchOp("replace", path, to))
+	}
+	return ops
+}
+
+func patchOp(op string, path *location, value any) Obj {
+	return Obj{"op": op, "path": path.pointer(), "value": clone(value)}
+}
+
+func applyPatch(doc any, patch Arr) (any, error) {
+	patch, err := normalize(patch)
+	if err != nil {
+		return doc, fmt.Errorf("%w %T: %v", ErrNormalize, patch, err)
+	}
+
+	for i, raw := range patch {
+		op, ok := raw.(Obj)
+		if !ok {
+			return doc, fmt.Errorf("patch operation %d must be an object, got %T", i, raw)
+		}
+		name, _ := op["op"].(string)
+		path, ok := op["path"].(string)
+		if !ok {
+			return doc, fmt.Errorf("patch operation %d (%s) is missing a path", i, name)
+		}
+
+		doc, err = applyPatchOp(doc, name, path, op)
+		if err != nil {
+			return doc, fmt.Errorf("patch operation %d (%s %s) failed: %w", i, name, path, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyPatchOp(doc any, name, path string, op Obj) (any, error) {
+	value, hasValue := op["value"]
+	from, hasFrom := op["from"].(string)
+
+	switch name {
+	case "add", "replace", "test":
+		if !hasValue {
+			return doc, errors.New("missing value")
+		}
+	case "move", "copy":
+		if !hasFrom {
+			return doc, errors.New("missing from")
+		}
+	}
+
+	switch name {
+	case "add":
+		return patchAdd(doc, path, clone(value))
+	case "remove":
+		if path == "" {
+			return doc, errors.New("cannot remove the root value")
+		}
+		return deleteByPath(doc, path)
+	case "replace":
+		target, err := findNode(path, doc)
+		if err != nil {
+			return doc, err
+		}
+		if target.set == nil {
+			return clone(value), nil
+		}
+		target.set(clone(value))
+		return doc, nil
+	case "move":
+		if from == path {
+			return doc, nil
+		}
+		if len(path) > len(from) && path[:len(from)+1] == from+"/" {
+			return doc, errors.New("cannot move a value into one of its children")
+		}
+		source, err := findNode(from, doc)
+		if err != nil {
+			return doc, err
+		}
+		if doc, err = deleteByPath(doc, from); err != nil {
+			return doc, err
+		}
+		return patchAdd(doc, path, source.value)
+	case "copy":
+		source, err := findNode(from, doc)
+		if err != nil {
+			return doc, err
+		}
+		return patchAdd(doc, path, clone(source.value))
+	case "test":
+		target, err := findNode(path, doc)
+		if err != nil {
+			return doc, err
+		}
+		if equal, diff := compareValues(target.path, value, target.value, equalOptions{}); !equal {
+			return doc, fmt.Errorf("test failed:\n%s", differences{diff}.report())
+		}
+		return doc, nil
+	default:
+		return doc, fmt.Errorf("unknown operation %q", name)
+	}
+}
+
+// patchAdd adds value at path as defined by the JSON Patch add operation: object keys are
+// created or replaced, array items are inserted before the index and "-" appends to the array.
+func patchAdd(doc any, path string, value any) (any, error) {
+	if path == "" {
+		return value, nil
+	}
+	p, err := parsePath(path)
+	if err != nil {
+		return doc, err
+	}
+	if len(p.segments) == 0 {
+		return value, nil
+	}
+	if !p.singular() {
+		return doc, fmt.Errorf("path '%s' must address a single value", path)
+	}
+
+	parent := node{value: doc}
+	if len(p.segments) > 1 {
+		parent, err = findPathNode(jsonPath{raw: path, segments: p.segments[:len(p.segments)-1]}, doc)
+		if err != nil {
+			return doc, err
+		}
+	}
+
+	last := p.segments[len(p.segments)-1].selectors[0]
+	switch container := parent.value.(type) {
+	case Obj:
+		if last.kind == indexSelector {
+			return doc, fmt.Errorf("invalid array index %d for object", last.index)
+		}
+		container[last.name] = value
+		return doc, nil
+	case Arr:
+		index, ok := len(container), last.kind == memberSelector && last.name == "-"
+		if !ok {
+			index, ok = last.arrayIndex(len(container))
+		}
+		if !ok {
+			return doc, fmt.Errorf("invalid array index: %s", last.name)
+		}
+		if index < 0 || index > len(container) {
+			return doc, fmt.Errorf("index %d out of bounds for array of length %d", index, len(container))
+		}
+		updated := slices.Insert(container, index, value)
+		if parent.set == nil {
+			return updated, nil
+		}
+		parent.set(updated)
+		return doc, nil
+	default:
+		return doc, fmt.Errorf("cannot add a value to %T", parent.value)
+	}
+}
+
+// findNode returns the node at a singular path, the root for the empty JSON pointer.
+func findNode(path string, doc any) (node, error) {
+	if path == "" {
+		return node{value: doc}, nil
+	}
+	p, err := parsePath(path)
+	if err != nil {
+		return node{}, err
+	}
+	return findPathNode(p, doc)
+}
+
+func findPathNode(p jsonPath, doc any) (node, error) {
+	if !p.singular() {
+		return node{}, fmt.Errorf("path '%s' must address a single value", p)
+	}
+	nodes := p.nodes(node{value: doc}, doc)
+	if len(nodes) == 0 {
+		_, err := lookupSingular(p, doc)
+		return node{}, err
+	}
+	return nodes[0], nil
+}
+
+// clone returns a deep copy of a normalized value.
+func clone(v any) any {
+	switch typed := v.(type) {
+	case Obj:
+		c := make(Obj, len(typed))
+		for k, val := range typed {
+			c[k] = clone(val)
+		}
+		return c
+	case Arr:
+		c := make(Arr, len(typed))
+		for i, val := range typed {
+			c[i] = clone(val)
+		}
+		return c
+	case Val:
+		return Val{Value: clone(typed.Value)}
+	}
+	return v
+}
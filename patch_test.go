@@ -0,0 +1,161 @@
+package jman_test
+
+import (
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObj_ApplyPatch(t *testing.T) {
+	testCases := []struct {
+		name  string
+		data  jman.Obj
+		patch string
+		want  jman.Obj
+	}{
+		{
+			name:  "AddKey",
+			data:  jman.Obj{"a": 1},
+			patch: `[{"op":"add","path":"/b","value":{"c":2}}]`,
+			want:  jman.Obj{"a": 1, "b": jman.Obj{"c": 2}},
+		},
+		{
+			name:  "AddArrayItem",
+			data:  jman.Obj{"items": jman.Arr{"a", "c"}},
+			patch: `[{"op":"add","path":"/items/1","value":"b"},{"op":"add","path":"/items/-","value":"d"}]`,
+			want:  jman.Obj{"items": jman.Arr{"a", "b", "c", "d"}},
+		},
+		{
+			name:  "Remove",
+			data:  jman.Obj{"a": 1, "items": jman.Arr{"a", "b"}},
+			patch: `[{"op":"remove","path":"/a"},{"op":"remove","path":"/items/0"}]`,
+			want:  jman.Obj{"items": jman.Arr{"b"}},
+		},
+		{
+			name:  "Replace",
+			data:  jman.Obj{"a": 1},
+			patch: `[{"op":"replace","path":"/a","value":[1,2]}]`,
+			want:  jman.Obj{"a": jman.Arr{1, 2}},
+		},
+		{
+			name:  "Move",
+			data:  jman.Obj{"a": jman.Obj{"b": 1}, "c": jman.Arr{}},
+			patch: `[{"op":"move","from":"/a/b","path":"/c/0"}]`,
+			want:  jman.Obj{"a": jman.Obj{}, "c": jman.Arr{1}},
+		},
+		{
+			name:  "Copy",
+			data:  jman.Obj{"a": jman.Obj{"b": 1}},
+			patch: `[{"op":"copy","from":"/a","path":"/c"}]`,
+			want:  jman.Obj{"a": jman.Obj{"b": 1}, "c": jman.Obj{"b": 1}},
+		},
+		{
+			name:  "Test",
+			data:  jman.Obj{"a": jman.Obj{"b": 1}},
+			patch: `[{"op":"test","path":"/a","value":{"b":1}}]`,
+			want:  jman.Obj{"a": jman.Obj{"b": 1}},
+		},
+		{
+			name:  "EscapedPointer",
+			data:  jman.Obj{"a/b": 1, "m~n": 2},
+			patch: `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`,
+			want:  jman.Obj{"a/b": 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.data
+			data.ApplyPatch(t, jman.New[jman.Arr](t, tc.patch))
+			data.Equal(t, tc.want)
+		})
+	}
+}
+
+func TestObj_ApplyPatch_FailedTestIsAtomic(t *testing.T) {
+	data := jman.Obj{"a": 1}
+	patch := jman.Arr{
+		jman.Obj{"op": "replace", "path": "/a", "value": 2},
+		jman.Obj{"op": "test", "path": "/a", "value": 3},
+	}
+
+	assertFatalf(t, `patch operation 1 (test /a) failed: test failed:
+$.a expected 3 - actual 2
+`, func(mt jman.T) {
+		data.ApplyPatch(mt, patch)
+	})
+	assert.Equal(t, jman.Obj{"a": 1}, data)
+}
+
+func TestApplyPatch_FailureWithoutAbortLeavesValueUnchanged(t *testing.T) {
+	patch := jman.Arr{
+		jman.Obj{"op": "replace", "path": "/0", "value": 2},
+		jman.Obj{"op": "test", "path": "/0", "value": 3},
+	}
+	obj := jman.Obj{"a": 1}
+	arr := jman.Arr{1}
+	ct := &continuingT{}
+
+	obj.ApplyPatch(ct, jman.Arr{jman.Obj{"op": "replace", "path": "/a", "value": 2}, jman.Obj{"op": "remove", "path": "/b"}})
+	arr.ApplyPatch(ct, patch)
+	ops := jman.Patch(ct, "nope", jman.Obj{})
+
+	assert.Len(t, ct.fatals, 3)
+	assert.Equal(t, jman.Obj{"a": 1}, obj)
+	assert.Equal(t, jman.Arr{1}, arr)
+	assert.Nil(t, ops)
+}
+
+func TestObj_ApplyPatch_MissingParent(t *testing.T) {
+	data := jman.Obj{"a": 1}
+	assertFatalf(t, "patch operation 0 (add /b/c) failed: key 'b' not found in object", func(mt jman.T) {
+		data.ApplyPatch(mt, jman.Arr{jman.Obj{"op": "add", "path": "/b/c", "value": 1}})
+	})
+}
+
+func TestArr_ApplyPatch(t *testing.T) {
+	data := jman.Arr{"a", "b"}
+	data.ApplyPatch(t, jman.Arr{
+		jman.Obj{"op": "add", "path": "/0", "value": "start"},
+		jman.Obj{"op": "remove", "path": "/2"},
+	})
+
+	assert.Equal(t, jman.Arr{"start", "a"}, data)
+}
+
+func TestPatch(t *testing.T) {
+	from := jman.Obj{
+		"name":    "alice",
+		"removed": true,
+		"tags":    jman.Arr{"a", "b", "c"},
+		"nested":  jman.Obj{"count": 1},
+	}
+	to := `{"name":"bob","added":1,"tags":["a","x"],"nested":{"count":1}}`
+
+	patch := jman.Patch(t, from, to)
+
+	jman.Equal(t, `[
+		{"op":"remove","path":"/removed"},
+		{"op":"add","path":"/added","value":1},
+		{"op":"replace","path":"/name","value":"bob"},
+		{"op":"replace","path":"/tags/1","value":"x"},
+		{"op":"remove","path":"/tags/2"}
+	]`, patch)
+
+	from.ApplyPatch(t, patch)
+	from.Equal(t, to)
+}
+
+func TestPatch_ArrayGrowsAndShrinks(t *testing.T) {
+	from := jman.Arr{1, 2, 3, 4}
+	to := jman.Arr{1}
+
+	patch := jman.Patch(t, from, to)
+	from.ApplyPatch(t, patch)
+	from.Equal(t, to)
+
+	patch = jman.Patch(t, to, jman.Arr{1, jman.Obj{"a": 1}, 3})
+	to.ApplyPatch(t, patch)
+	to.Equal(t, jman.Arr{1, jman.Obj{"a": 1}, 3})
+}
@@ -0,0 +1,523 @@
+package jman
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonPath is a parsed JSONPath expression. It supports the RFC 9535 subset of
+// child and descendant segments with name, index, wildcard, slice and filter selectors,
+// as well as the legacy dot syntax where a numeric segment addresses an array index.
+type jsonPath struct {
+	raw      string
+	segments []segment
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type selectorKind int
+
+const (
+	// memberSelector comes from the dot syntax and addresses a key on objects,
+	// or an index on arrays when the member is numeric.
+	memberSelector selectorKind = iota
+	nameSelector
+	indexSelector
+	wildcardSelector
+	sliceSelector
+	filterSelector
+)
+
+type selector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	slice  slice
+	filter expr
+}
+
+type slice struct {
+	start, end *int
+	step       int
+}
+
+func (p jsonPath) String() string {
+	return p.raw
+}
+
+// singular reports whether the path can address at most one value.
+func (p jsonPath) singular() bool {
+	for _, seg := range p.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].kind {
+		case memberSelector, nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func parsePath(path string) (jsonPath, error) {
+	if path == "" {
+		return jsonPath{}, errors.New("path cannot be empty")
+	}
+	if path[0] == '/' {
+		return parsePointer(path)
+	}
+	if path[0] != base[0] {
+		return jsonPath{}, fmt.Errorf("path must start with %s or be a JSON pointer starting with /", base)
+	}
+
+	p := &pathParser{src: path, pos: 1}
+	segments, err := p.segments()
+	if err != nil {
+		return jsonPath{}, fmt.Errorf("invalid path '%s': %w", path, err)
+	}
+	if p.pos != len(p.src) {
+		return jsonPath{}, fmt.Errorf("invalid path '%s': unexpected character %q at position %d", path, p.src[p.pos], p.pos)
+	}
+
+	return jsonPath{raw: path, segments: segments}, nil
+}
+
+// parsePointer parses an RFC 6901 JSON pointer such as /a/b~1c/0. Each reference token
+// addresses a key on objects, or an index on arrays when the token is numeric.
+func parsePointer(pointer string) (jsonPath, error) {
+	tokens := strings.Split(pointer[1:], "/")
+	segments := make([]segment, len(tokens))
+	for i, token := range tokens {
+		if strings.Contains(strings.ReplaceAll(strings.ReplaceAll(token, "~0", ""), "~1", ""), "~") {
+			return jsonPath{}, fmt.Errorf("invalid JSON pointer '%s': invalid escape in %q", pointer, token)
+		}
+		name := strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		segments[i] = segment{selectors: []selector{{kind: memberSelector, name: name}}}
+	}
+	return jsonPath{raw: pointer, segments: segments}, nil
+}
+
+type pathParser struct {
+	src string
+	pos int
+	// inFilter restricts dot member names to identifier characters so that
+	// operators inside filter expressions terminate the path.
+	inFilter bool
+}
+
+func (p *pathParser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *pathParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.src[p.pos:], prefix)
+}
+
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\n\r", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *pathParser) segments() ([]segment, error) {
+	var segments []segment
+	for p.pos < len(p.src) {
+		var (
+			seg segment
+			err error
+		)
+		switch {
+		case p.hasPrefix(".."):
+			p.pos += 2
+			seg, err = p.dotOrBracket()
+			seg.descendant = true
+		case p.peek() == '.':
+			p.pos++
+			seg, err = p.dotOrBracket()
+		case p.peek() == '[':
+			seg, err = p.bracket()
+		case p.inFilter:
+			return segments, nil
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", p.peek(), p.pos)
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+func (p *pathParser) dotOrBracket() (segment, error) {
+	switch p.peek() {
+	case '[':
+		return p.bracket()
+	case '*':
+		p.pos++
+		return segment{selectors: []selector{{kind: wildcardSelector}}}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && p.isNameChar() {
+		p.pos++
+	}
+	if start == p.pos {
+		return segment{}, fmt.Errorf("empty segment at position %d", start)
+	}
+	return segment{selectors: []selector{{kind: memberSelector, name: p.src[start:p.pos]}}}, nil
+}
+
+func (p *pathParser) isNameChar() bool {
+	c := p.src[p.pos]
+	if !p.inFilter {
+		return c != '.' && c != '['
+	}
+	return c == '_' || c == '-' || c >= utf8.RuneSelf ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *pathParser) bracket() (segment, error) {
+	p.pos++ // skip [
+	var seg segment
+	for {
+		p.skipSpaces()
+		sel, err := p.selector()
+		if err != nil {
+			return segment{}, err
+		}
+		seg.selectors = append(seg.selectors, sel)
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return seg, nil
+		default:
+			return segment{}, fmt.Errorf("expected ',' or ']' at position %d", p.pos)
+		}
+	}
+}
+
+func (p *pathParser) selector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.quoted()
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: nameSelector, name: name}, nil
+	case c == '*':
+		p.pos++
+		return selector{kind: wildcardSelector}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		inFilter := p.inFilter
+		p.inFilter = true
+		e, err := p.orExpr()
+		p.inFilter = inFilter
+		if err != nil {
+			return selector{}, err
+		}
+		return selector{kind: filterSelector, filter: e}, nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.indexOrSlice()
+	case c == 0:
+		return selector{}, errors.New("unterminated bracket")
+	default:
+		return selector{}, fmt.Errorf("unexpected character %q at position %d", c, p.pos)
+	}
+}
+
+func (p *pathParser) indexOrSlice() (selector, error) {
+	var bounds [3]*int
+	part := 0
+	for {
+		p.skipSpaces()
+		if n, ok, err := p.integer(); err != nil {
+			return selector{}, err
+		} else if ok {
+			bounds[part] = &n
+		}
+		p.skipSpaces()
+		if p.peek() != ':' {
+			break
+		}
+		if part == 2 {
+			return selector{}, fmt.Errorf("too many ':' in slice at position %d", p.pos)
+		}
+		p.pos++
+		part++
+	}
+
+	if part == 0 {
+		if bounds[0] == nil {
+			return selector{}, fmt.Errorf("expected index at position %d", p.pos)
+		}
+		return selector{kind: indexSelector, index: *bounds[0]}, nil
+	}
+
+	s := slice{start: bounds[0], end: bounds[1], step: 1}
+	if bounds[2] != nil {
+		s.step = *bounds[2]
+	}
+	return selector{kind: sliceSelector, slice: s}, nil
+}
+
+func (p *pathParser) integer() (int, bool, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid integer %q at position %d", p.src[start:p.pos], start)
+	}
+	return n, true, nil
+}
+
+// quoted reads a single or double quoted string literal, supporting the JSON escape sequences.
+func (p *pathParser) quoted() (string, error) {
+	quote := p.src[p.pos]
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", fmt.Errorf("unterminated string at position %d", start)
+			}
+			p.pos++
+			switch esc := p.src[p.pos]; esc {
+			case '\'', '"', '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if p.pos+4 >= len(p.src) {
+					return "", fmt.Errorf("invalid unicode escape at position %d", p.pos)
+				}
+				r, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					return "", fmt.Errorf("invalid unicode escape at position %d", p.pos)
+				}
+				sb.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				return "", fmt.Errorf("invalid escape %q at position %d", esc, p.pos)
+			}
+			p.pos++
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", fmt.Errorf("unterminated string at position %d", start)
+}
+
+// location is the concrete position of a value within a document, used to report differences.
+// The root of the document is a nil *location.
+type location struct {
+	parent  *location
+	key     string
+	index   int
+	isIndex bool
+	// label identifies an array item by its key fields instead of its index, e.g. id=42.
+	label string
+}
+
+func (l *location) child(key string) *location {
+	return &location{parent: l, key: key}
+}
+
+func (l *location) item(index int) *location {
+	return &location{parent: l, index: index, isIndex: true}
+}
+
+// keyedItem is the array item at index, displayed by its label, e.g. $.users[id=42].
+func (l *location) keyedItem(index int, label string) *location {
+	return &location{parent: l, index: index, isIndex: true, label: label}
+}
+
+// steps returns the locations from the first child of the root down to l.
+func (l *location) steps() []*location {
+	var steps []*location
+	for curr := l; curr != nil; curr = curr.parent {
+		steps = append(steps, curr)
+	}
+	slices.Reverse(steps)
+	return steps
+}
+
+// String renders the location in dot syntax, e.g. $.users.0.email. Keys that would be
+// ambiguous in dot syntax, such as "a.b", "" or "42", are rendered in brackets: $['a.b'].
+// Array items matched by key are rendered with their label: $.users[id=42].
+func (l *location) String() string {
+	var sb strings.Builder
+	sb.WriteString(base)
+	for _, step := range l.steps() {
+		switch {
+		case step.label != "":
+			sb.WriteString("[" + step.label + "]")
+		case step.isIndex:
+			sb.WriteString("." + strconv.Itoa(step.index))
+		case needsBrackets(step.key):
+			sb.WriteString("['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(step.key) + "']")
+		default:
+			sb.WriteString("." + step.key)
+		}
+	}
+	return sb.String()
+}
+
+// pointer renders the location as an RFC 6901 JSON pointer, e.g. /users/0/email. The root is "".
+func (l *location) pointer() string {
+	var sb strings.Builder
+	for _, step := range l.steps() {
+		sb.WriteByte('/')
+		if step.isIndex {
+			sb.WriteString(strconv.Itoa(step.index))
+			continue
+		}
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(step.key))
+	}
+	return sb.String()
+}
+
+func needsBrackets(key string) bool {
+	return key == "" || key == "*" || isIndex(key) || strings.ContainsAny(key, ".[]'\"\\ ")
+}
+
+// positional returns an error if the path has selectors that depend on the values compared rather than on
+// locations alone, which matches can't resolve: filters, negative indices, and slices counting from the end
+// or backwards.
+func (p jsonPath) positional() error {
+	for _, seg := range p.segments {
+		for _, sel := range seg.selectors {
+			switch {
+			case sel.kind == filterSelector:
+				return fmt.Errorf("path '%s' can't be used in options: filters depend on the values compared", p)
+			case sel.kind == indexSelector && sel.index < 0:
+				return fmt.Errorf("path '%s' can't be used in options: negative index %d depends on the array length", p, sel.index)
+			case sel.kind == sliceSelector && (sel.slice.step <= 0 || negative(sel.slice.start) || negative(sel.slice.end)):
+				return fmt.Errorf("path '%s' can't be used in options: slices with negative bounds or steps depend on the array length", p)
+			}
+		}
+	}
+	return nil
+}
+
+func negative(i *int) bool {
+	return i != nil && *i < 0
+}
+
+// matches reports whether the path selects the location. Filter selectors never match
+// since they depend on values rather than positions.
+func (p jsonPath) matches(l *location) bool {
+	return matchSegments(p.segments, l.steps())
+}
+
+// matchesWithin reports whether the path can select l or any location nested in it.
+func (p jsonPath) matchesWithin(l *location) bool {
+	return matchPrefix(p.segments, l.steps())
+}
+
+func matchPrefix(segments []segment, steps []*location) bool {
+	if len(steps) == 0 {
+		return true
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	seg := segments[0]
+	if !seg.descendant {
+		return seg.matches(steps[0]) && matchPrefix(segments[1:], steps[1:])
+	}
+	// a descendant segment can always match below the last step
+	return true
+}
+
+func matchSegments(segments []segment, steps []*location) bool {
+	if len(segments) == 0 {
+		return len(steps) == 0
+	}
+	seg := segments[0]
+	if !seg.descendant {
+		return len(steps) > 0 && seg.matches(steps[0]) && matchSegments(segments[1:], steps[1:])
+	}
+	for i, step := range steps {
+		if seg.matches(step) && matchSegments(segments[1:], steps[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (seg segment) matches(step *location) bool {
+	return slices.ContainsFunc(seg.selectors, func(sel selector) bool {
+		return sel.matches(step)
+	})
+}
+
+func (sel selector) matches(step *location) bool {
+	switch sel.kind {
+	case memberSelector:
+		if step.isIndex {
+			return sel.name == strconv.Itoa(step.index)
+		}
+		return sel.name == step.key
+	case nameSelector:
+		return !step.isIndex && sel.name == step.key
+	case indexSelector:
+		return step.isIndex && sel.index == step.index
+	case wildcardSelector:
+		return true
+	case sliceSelector:
+		if !step.isIndex || sel.slice.step <= 0 {
+			return false
+		}
+		start, end := 0, step.index+1
+		if sel.slice.start != nil {
+			start = *sel.slice.start
+		}
+		if sel.slice.end != nil {
+			end = *sel.slice.end
+		}
+		return start >= 0 && step.index >= start && step.index < end && (step.index-start)%sel.slice.step == 0
+	}
+	return false
+}
@@ -1,70 +1,292 @@
 package jman
 
 import (
+	"errors"
 	"fmt"
 	"maps"
-	"strconv"
+	"slices"
 )
 
 func setByPath(data any, path string, value any) error {
-	paths, err := getPathParts(path)
+	p, err := parsePath(path)
 	if err != nil {
 		return err
 	}
+	if len(p.segments) == 0 {
+		return errors.New("cannot set the root value")
+	}
+	// filters compare against normalized values, so normalize before evaluating the path
+	if err := normalizeInPlace(data); err != nil {
+		return err
+	}
 
-	current := data
+	last := p.segments[len(p.segments)-1]
+	var parents []node
+	if p.singular() {
+		// singular paths create any missing objects and arrays along the way
+		parent, err := createPath(node{value: data}, p.segments)
+		if err != nil {
+			return err
+		}
+		parents = []node{parent}
+	} else {
+		parents = jsonPath{segments: p.segments[:len(p.segments)-1]}.nodes(node{value: data}, data)
+	}
 
-	for i, p := range paths { // skip the base part
-		if p == base {
+	var targets []node
+	for _, parent := range parents {
+		if !last.creates() {
+			targets = last.apply(parent, data, targets)
 			continue
 		}
-		isLast := i == len(paths)-1
+		for _, sel := range last.selectors {
+			target, err := childOrCreate(parent, sel, func() any { return nil })
+			if err != nil {
+				return err
+			}
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("path '%s' matched no values", path)
+	}
+	for _, target := range targets {
+		target.set(value)
+	}
 
-		switch curr := current.(type) {
-		case Obj:
-			if isLast {
-				curr[p] = value
-				return nil
+	return normalizeInPlace(data)
+}
+
+// deleteByPath removes every key or array item matched by the path and returns the resulting root,
+// which differs from data when an item of the root array is removed.
+func deleteByPath(data any, path string) (any, error) {
+	p, err := parsePath(path)
+	if err != nil {
+		return data, err
+	}
+	if len(p.segments) == 0 {
+		return data, errors.New("cannot delete the root value")
+	}
+	if err := normalizeInPlace(data); err != nil {
+		return data, err
+	}
+
+	targets := p.nodes(node{value: data}, data)
+	if len(targets) == 0 {
+		if p.singular() {
+			if _, err := lookupSingular(p, data); err != nil {
+				return data, err
 			}
-			next, ok := curr[p]
-			if !ok {
-				if isIndex(paths[i+1]) {
-					curr[p] = Arr{}
-				} else {
-					curr[p] = Obj{}
-				}
-				next = curr[p]
+		}
+		return data, fmt.Errorf("path '%s' matched no values", path)
+	}
+
+	// a union like $[0,0] matches a node more than once, but it must only be removed once, and
+	// a descendant path like $..[0] can match a node and one of its descendants, which is removed with it
+	seen := map[string]bool{}
+	targets = slices.DeleteFunc(targets, func(target node) bool {
+		key := target.path.String()
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+		return false
+	})
+	targets = slices.DeleteFunc(targets, func(target node) bool {
+		for ancestor := target.path.parent; ancestor != nil; ancestor = ancestor.parent {
+			if seen[ancestor.String()] {
+				return true
 			}
-			current = next
+		}
+		return false
+	})
+
+	// remove array items from the highest index down so the remaining indices stay valid
+	slices.SortStableFunc(targets, func(a, b node) int {
+		switch {
+		case a.path.isIndex && b.path.isIndex:
+			return b.path.index - a.path.index
+		case a.path.isIndex:
+			return 1
+		case b.path.isIndex:
+			return -1
+		}
+		return 0
+	})
+
+	shrunk := map[string]Arr{}
+	parents := map[string]*node{}
+	for _, target := range targets {
+		switch parent := target.parent.value.(type) {
+		case Obj:
+			delete(parent, target.path.key)
 		case Arr:
-			idx, err := strconv.Atoi(p)
-			if err != nil || idx < 0 {
-				return fmt.Errorf("invalid array index: %s", p)
-			}
-			for len(curr) <= idx {
-				curr = append(curr, nil)
-			}
-			if isLast {
-				curr[idx] = value
-				return nil
-			}
-			if curr[idx] == nil {
-				if isIndex(paths[i+1]) {
-					curr[idx] = Arr{}
-				} else {
-					curr[idx] = Obj{}
-				}
-			}
-			current = curr[idx]
-			// must assign back in case the array grew
-			if parent, ok := current.([]any); ok {
-				current = Arr(parent)
+			key := target.parent.path.String()
+			arr, ok := shrunk[key]
+			if !ok {
+				arr = parent
 			}
+			shrunk[key] = slices.Delete(arr, target.path.index, target.path.index+1)
+			parents[key] = target.parent
+		}
+	}
+
+	for key, arr := range shrunk {
+		if parents[key].set == nil {
+			data = arr
+			continue
+		}
+		parents[key].set(arr)
+	}
+
+	return data, normalizeInPlace(data)
+}
+
+// appendByPath appends values to every array matched by the path and returns the resulting root.
+// A missing array at a singular path is created.
+func appendByPath(data any, path string, values ...any) (any, error) {
+	return updateArrays(data, path, func(arr Arr) (Arr, error) {
+		return append(arr, values...), nil
+	})
+}
+
+// insertByPath inserts value at index into every array matched by the path and returns the resulting root.
+func insertByPath(data any, path string, index int, value any) (any, error) {
+	return updateArrays(data, path, func(arr Arr) (Arr, error) {
+		if index < 0 || index > len(arr) {
+			return nil, fmt.Errorf("index %d out of bounds for array of length %d", index, len(arr))
+		}
+		return slices.Insert(arr, index, value), nil
+	})
+}
+
+func updateArrays(data any, path string, update func(arr Arr) (Arr, error)) (any, error) {
+	p, err := parsePath(path)
+	if err != nil {
+		return data, err
+	}
+	if err := normalizeInPlace(data); err != nil {
+		return data, err
+	}
+
+	root := node{value: data}
+	var targets []node
+	switch {
+	case len(p.segments) == 0:
+		targets = []node{root}
+	case p.singular():
+		parent, err := createPath(root, p.segments)
+		if err != nil {
+			return data, err
+		}
+		target, err := childOrCreate(parent, p.segments[len(p.segments)-1].selectors[0], func() any { return Arr{} })
+		if err != nil {
+			return data, err
+		}
+		targets = []node{target}
+	default:
+		targets = p.nodes(root, data)
+	}
+	if len(targets) == 0 {
+		return data, fmt.Errorf("path '%s' matched no values", path)
+	}
+
+	for _, target := range targets {
+		arr, ok := target.value.(Arr)
+		if !ok {
+			return data, fmt.Errorf("expected array at path '%s', got %T", target.path, target.value)
+		}
+		updated, err := update(arr)
+		if err != nil {
+			return data, err
+		}
+		if target.set == nil {
+			data = updated
+			continue
+		}
+		target.set(updated)
+	}
+
+	return data, normalizeInPlace(data)
+}
+
+// creates reports whether the segment names keys or indices that can be created when missing.
+func (seg segment) creates() bool {
+	if seg.descendant {
+		return false
+	}
+	for _, sel := range seg.selectors {
+		switch sel.kind {
+		case memberSelector, nameSelector, indexSelector:
 		default:
-			return fmt.Errorf("unexpected type at segment %s", p)
+			return false
 		}
 	}
+	return true
+}
+
+func createPath(current node, segments []segment) (node, error) {
+	for i, seg := range segments[:len(segments)-1] {
+		next := segments[i+1].selectors[0]
+		var err error
+		current, err = childOrCreate(current, seg.selectors[0], func() any { return containerFor(next) })
+		if err != nil {
+			return node{}, err
+		}
+	}
+	return current, nil
+}
 
+// containerFor returns the container needed to hold sel: an Arr for indices, an Obj otherwise.
+func containerFor(sel selector) any {
+	if sel.kind == indexSelector || (sel.kind == memberSelector && isIndex(sel.name)) {
+		return Arr{}
+	}
+	return Obj{}
+}
+
+// childOrCreate returns the child of n addressed by sel, creating it with create if it does not exist.
+// Arrays are grown with null items up to the addressed index.
+func childOrCreate(n node, sel selector, create func() any) (node, error) {
+	if n.value == nil && n.set != nil {
+		container := containerFor(sel)
+		n.set(container)
+		n.value = container
+	}
+
+	switch curr := n.value.(type) {
+	case Obj:
+		if sel.kind == indexSelector {
+			return node{}, fmt.Errorf("invalid array index %d for object", sel.index)
+		}
+		if _, ok := curr[sel.name]; !ok {
+			curr[sel.name] = create()
+		}
+		return objNode(&n, curr, sel.name), nil
+	case Arr:
+		idx, ok := sel.arrayIndex(len(curr))
+		if !ok || idx < 0 {
+			return node{}, fmt.Errorf("invalid array index: %s", sel.name)
+		}
+		if idx >= len(curr) {
+			if n.set == nil {
+				return node{}, fmt.Errorf("index %d out of bounds for array of length %d", idx, len(curr))
+			}
+			curr = append(curr, make(Arr, idx+1-len(curr))...)
+			n.set(curr)
+			n.value = curr
+		}
+		if curr[idx] == nil {
+			curr[idx] = create()
+		}
+		return arrNode(&n, curr, idx), nil
+	default:
+		return node{}, fmt.Errorf("unexpected type at segment %s", sel.name)
+	}
+}
+
+// normalizeInPlace normalizes an Obj or Arr without changing its identity.
+func normalizeInPlace(data any) error {
 	switch v := data.(type) {
 	case Obj:
 		normalized, err := normalize(v)
@@ -79,6 +301,5 @@ func setByPath(data any, path string, value any) error {
 		}
 		copy(v, normalized)
 	}
-
 	return nil
 }
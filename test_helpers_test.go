@@ -1,6 +1,7 @@
 package jman_test
 
 import (
+	"runtime"
 	"strings"
 	"testing"
 
@@ -13,17 +14,50 @@ type MockT struct {
 	mock.Mock
 	gotMsg  string
 	wantMsg string
+	// helpers are the functions that called Helper, unmarked the jman functions
+	// on the stack of a failure that did not.
+	helpers  map[string]bool
+	unmarked []string
 }
 
 func (m *MockT) Fatalf(format string, args ...any) {
 	m.Called(format)
 	m.gotMsg = format
+	m.checkHelpers()
 	panic(format) // panic to simulate Fatalf behavior
 }
 
+// Helper records the calling function like testing.T does.
+func (m *MockT) Helper() {
+	if m.helpers == nil {
+		m.helpers = map[string]bool{}
+	}
+	pc := make([]uintptr, 1)
+	runtime.Callers(2, pc)
+	frame, _ := runtime.CallersFrames(pc).Next()
+	m.helpers[frame.Function] = true
+}
+
+// checkHelpers records every jman function on the stack of a failure that did not call Helper.
+func (m *MockT) checkHelpers() {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "github.com/akaswenwilk/jman.") && !m.helpers[frame.Function] {
+			m.unmarked = append(m.unmarked, frame.Function)
+		}
+		if !more {
+			return
+		}
+	}
+}
+
 func (m *MockT) AssertExpectations(t *testing.T) {
 	t.Helper()
 	m.Mock.AssertExpectations(t)
+	assert.Empty(t, m.unmarked, "jman functions on the stack of a failure should call t.Helper()")
 	gotMsgLines := strings.Split(m.gotMsg, "\n")
 	wantMsgLines := strings.Split(m.wantMsg, "\n")
 	assert.Equal(t, len(wantMsgLines), len(gotMsgLines), "number of lines in error message should match")
@@ -44,3 +78,27 @@ func assertFatalf(t *testing.T, expectedMsg string, fn func(t jman.T)) {
 	defer mt.AssertExpectations(t)
 	assert.Panics(t, func() { fn(mt) })
 }
+
+// SoftMockT records Errorf calls, while Fatalf still panics like MockT.
+type SoftMockT struct {
+	*MockT
+	errors []string
+}
+
+func (m *SoftMockT) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, format)
+	m.checkHelpers()
+}
+
+func newSoftMockT() *SoftMockT {
+	return &SoftMockT{MockT: newMockT("")}
+}
+
+// continuingT records Fatalf calls without stopping, like a T whose Fatalf does not call runtime.Goexit.
+type continuingT struct {
+	fatals []string
+}
+
+func (c *continuingT) Fatalf(format string, args ...any) {
+	c.fatals = append(c.fatals, format)
+}
@@ -0,0 +1,96 @@
+package jman_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_FloatTolerance(t *testing.T) {
+	expected := jman.Obj{"total": 0.3, "items": jman.Arr{1.1, 2.2}}
+	actual := jman.Obj{"total": 0.1 + 0.2, "items": jman.Arr{1.1000000001, 2.2}}
+
+	jman.Equal(t, expected, actual, jman.WithFloatTolerance(1e-6))
+}
+
+func TestDiff_FloatTolerance_ShowsDelta(t *testing.T) {
+	diffs := jman.Diff(t, jman.Obj{"price": 10.0}, jman.Obj{"price": 10.5}, jman.WithFloatTolerance(0.25))
+
+	assert.Equal(t, []string{"$.price expected 10 - actual 10.5, delta 0.5 exceeds tolerance 0.25"}, diffStrings(diffs))
+}
+
+func TestDiff_FloatTolerance_UseNumber_LargeIntegers(t *testing.T) {
+	diffs := jman.Diff(t, `{"id": 9007199254740993}`, `{"id": 9007199254740992}`, jman.WithUseNumber(), jman.WithFloatTolerance(0))
+
+	assert.Equal(t, []string{"$.id expected 9007199254740993 - actual 9007199254740992, delta 1 exceeds tolerance 0"}, diffStrings(diffs))
+
+	jman.Equal(t, `{"id": 9007199254740993}`, `{"id": 9007199254740992}`, jman.WithUseNumber(), jman.WithFloatTolerance(1))
+}
+
+func TestDiff_FloatTolerance_TypeMismatch(t *testing.T) {
+	diffs := jman.Diff(t, jman.Obj{"price": 10.0}, jman.Obj{"price": "10"}, jman.WithFloatTolerance(1))
+
+	assert.Equal(t, []string{`$.price expected 10 - actual "10"`}, diffStrings(diffs))
+	assert.Equal(t, jman.DiffTypeMismatch, diffs[0].Kind)
+}
+
+func TestDiff_FloatToleranceAt(t *testing.T) {
+	expected := jman.Obj{"items": jman.Arr{jman.Obj{"price": 9.99, "qty": 2}}, "total": 19.98}
+	actual := jman.Obj{"items": jman.Arr{jman.Obj{"price": 9.991, "qty": 2.001}}, "total": 19.981}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithFloatToleranceAt("$.items.*.price", 0.01))
+
+	assert.ElementsMatch(t, []string{
+		"$.total expected 19.98 - actual 19.981",
+		"$.items.0.qty expected 2 - actual 2.001",
+	}, diffStrings(diffs))
+}
+
+func TestDiff_FloatToleranceAt_Nested(t *testing.T) {
+	expected := jman.Obj{"stats": jman.Obj{"mean": 1.5, "p99": jman.Arr{3.0}}, "count": 10}
+	actual := jman.Obj{"stats": jman.Obj{"mean": 1.51, "p99": jman.Arr{3.04}}, "count": 10}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithFloatToleranceAt("/stats", 0.05))
+
+	assert.Empty(t, diffs)
+}
+
+func TestDiff_RelativeFloatTolerance(t *testing.T) {
+	expected := jman.Arr{1000.0, 0.001, -200.0}
+	actual := jman.Arr{1009.0, 0.00102, -201.9}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithRelativeFloatTolerance(0.01))
+
+	assert.Equal(t, []string{"$.1 expected 0.001 - actual 0.00102, delta 2.0000000000000052e-05 exceeds tolerance 1e-05"}, diffStrings(diffs))
+}
+
+func TestDiff_RelativeFloatToleranceAt_LargestTolerance(t *testing.T) {
+	expected := jman.Obj{"amount": 0.0, "rate": 50.0}
+	actual := jman.Obj{"amount": 0.0004, "rate": 50.4}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithRelativeFloatToleranceAt("$.rate", 0.01), jman.WithFloatTolerance(0.001))
+
+	assert.Empty(t, diffs)
+}
+
+func TestDiff_FloatTolerance_IgnoreOrder(t *testing.T) {
+	expected := jman.Arr{jman.Obj{"id": "a", "score": 0.3}, jman.Obj{"id": "b", "score": 0.7}}
+	actual := jman.Arr{jman.Obj{"id": "b", "score": 0.70001}, jman.Obj{"id": "a", "score": 0.1 + 0.2}}
+
+	diffs := jman.Diff(t, expected, actual, jman.WithIgnoreArrayOrder("$"), jman.WithFloatToleranceAt("$.*.score", 0.001))
+
+	assert.Empty(t, diffs)
+}
+
+func TestCompare_FloatTolerance_Invalid(t *testing.T) {
+	for _, epsilon := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err := jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithFloatTolerance(epsilon))
+
+		assert.ErrorContains(t, err, "invalid options: float tolerance must be a non-negative number")
+	}
+
+	_, err := jman.Compare(jman.Obj{}, jman.Obj{}, jman.WithFloatToleranceAt("items", 1))
+	assert.Error(t, err)
+}
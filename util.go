@@ -1,23 +1,27 @@
 package jman
 
-import (
-	"errors"
-	"fmt"
-	"strings"
-)
-
 type T interface {
 	Fatalf(msg string, args ...any)
 }
 
-func getPathParts(path string) ([]string, error) {
-	if path == "" {
-		return nil, errors.New("path cannot be empty")
+// helperT is implemented by testing.TB. Every function taking a T calls Helper when it is available,
+// so that failures are reported at the line in the test rather than inside jman.
+type helperT interface {
+	Helper()
+}
+
+type errorfT interface {
+	Errorf(format string, args ...any)
+}
+
+// errorf reports a failure with Errorf if t supports it, otherwise with Fatalf.
+func errorf(t T, msg string) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
 	}
-	parts := strings.Split(path, ".")
-	if parts[0] != base {
-		return nil, fmt.Errorf("path must start with %s", base)
+	if et, ok := t.(errorfT); ok {
+		et.Errorf(msg)
+		return
 	}
-
-	return parts, nil
+	t.Fatalf(msg)
 }
@@ -0,0 +1,41 @@
+package jman
+
+import (
+	"encoding/json"
+)
+
+// Val represents a JSON document whose root is a scalar or null, e.g. "ok", 42, true or null.
+// It implements the JSONEqual interface, so bare values can be compared like objects and arrays,
+// e.g. jman.Equal(t, jman.Val{Value: "$UUID"}, body, jman.WithMatchers(jman.IsUUID("$UUID"))).
+// The Value is normalized like the values of an Obj, so it can be a placeholder.
+type Val struct {
+	Value any
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Val. Nested arrays and objects
+// are converted to Arr and Obj, and numbers to float64.
+func (v *Val) UnmarshalJSON(data []byte) error {
+	return v.unmarshal(data, floatNumbers)
+}
+
+func (v *Val) unmarshal(data []byte, numbers numberMode) error {
+	var raw any
+	if err := decodeJSON(data, &raw, numbers); err != nil {
+		return err
+	}
+	v.Value = convert(raw)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Val, encoding its Value.
+func (v Val) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+// Equal checks if the Val is equal to another value, which can be a JSON string, byte slice, or another Val.
+func (v Val) Equal(t T, other any, optFuncs ...optsFunc) {
+	if h, ok := t.(helperT); ok {
+		h.Helper()
+	}
+	Equal(t, v, other, optFuncs...)
+}
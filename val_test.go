@@ -0,0 +1,77 @@
+package jman_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akaswenwilk/jman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual_ScalarRoot(t *testing.T) {
+	jman.Equal(t, `"ok"`, []byte(`"ok"`))
+	jman.Equal(t, jman.Val{Value: 42}, "42")
+	jman.Equal(t, true, []byte(" true\n"))
+	jman.Equal(t, jman.Val{}, "null")
+	jman.Equal(t, nil, []byte("null"))
+}
+
+func TestEqual_ScalarRoot_Placeholder(t *testing.T) {
+	body := []byte(`"550e8400-e29b-41d4-a716-446655440000"`)
+
+	jman.Equal(t, "$UUID", body, jman.WithMatchers(jman.IsUUID("$UUID")))
+	jman.Equal(t, jman.Val{Value: "$UUID"}, body, jman.WithMatchers(jman.IsUUID("$UUID")))
+	jman.Val{Value: "$UUID"}.Equal(t, body, jman.WithMatchers(jman.IsUUID("$UUID")))
+}
+
+func TestEqual_ScalarRoot_NotEqual(t *testing.T) {
+	expectedMsg := `expected not equal to actual:
+expected "ok"
+actual "error"
+
+$ expected "ok" - actual "error"
+`
+	assertFatalf(t, expectedMsg, func(mt jman.T) {
+		jman.Equal(mt, `"ok"`, `"error"`)
+	})
+}
+
+func TestDiff_ScalarRoot_TypeMismatch(t *testing.T) {
+	diffs := jman.Diff(t, jman.Obj{"status": "ok"}, "42")
+
+	assert.Equal(t, []jman.Difference{{
+		Path:     "$",
+		Kind:     jman.DiffTypeMismatch,
+		Expected: jman.Obj{"status": "ok"},
+		Actual:   float64(42),
+		Message:  "expected object - got float64 (42)",
+	}}, diffs)
+
+	diffs = jman.Diff(t, "null", `[1]`)
+	assert.Equal(t, []string{"$ expected <nil> - actual [1]"}, diffStrings(diffs))
+}
+
+func TestCompare_ScalarRoot_InvalidJSON(t *testing.T) {
+	_, err := jman.Compare("nope", "1")
+
+	assert.EqualError(t, err, "error parsing JSON data nope: invalid character 'o' in literal null (expecting 'u')")
+}
+
+func TestNew_Val(t *testing.T) {
+	assert.Equal(t, jman.Val{Value: "ok"}, jman.New[jman.Val](t, `"ok"`))
+	assert.Equal(t, jman.Val{Value: jman.Obj{"a": 1.0}}, jman.New[jman.Val](t, jman.Val{Value: map[string]int{"a": 1}}))
+	assert.Equal(t, jman.Val{Value: json.Number("9007199254740993")}, jman.New[jman.Val](t, "9007199254740993", jman.WithUseNumber()))
+
+	marshaled, err := json.Marshal(jman.Val{Value: 1.5})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", string(marshaled))
+}
+
+func TestCompare_ScalarRoot_Report(t *testing.T) {
+	report, err := jman.Compare(jman.Val{Value: 42}, `"42"`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), report.Expected)
+	assert.Equal(t, "42", report.Actual)
+	assert.False(t, report.Equal())
+}